@@ -0,0 +1,42 @@
+package iter
+
+import "testing"
+
+var benchInput = func() []int {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}()
+
+func BenchmarkFusion(b *testing.B) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	square := func(x int) int { return x * x }
+	isSmall := func(x int) bool { return x < 1000000 }
+	sum := 0
+	add := func(x int) { sum += x }
+	run := func(b *testing.B, fuse bool) {
+		defer func(old, oldBatch bool) { fuseStages, batchStages = old, oldBatch }(fuseStages, batchStages)
+		fuseStages, batchStages = fuse, false
+		for i := 0; i < b.N; i++ {
+			FromSlice(benchInput).Filter(isEven).Map(square).Filter(isSmall).Inspect(add).Count()
+		}
+	}
+	b.Run("fused", func(b *testing.B) { run(b, true) })
+	b.Run("unfused", func(b *testing.B) { run(b, false) })
+}
+
+func BenchmarkBatches(b *testing.B) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	half := func(x int) int { return x / 2 }
+	run := func(b *testing.B, batch bool) {
+		defer func(old bool) { batchStages = old }(batchStages)
+		batchStages = batch
+		for i := 0; i < b.N; i++ {
+			FromSlice(benchInput).Filter(isEven).Map(half).Count()
+		}
+	}
+	b.Run("batched", func(b *testing.B) { run(b, true) })
+	b.Run("unbatched", func(b *testing.B) { run(b, false) })
+}
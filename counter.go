@@ -0,0 +1,101 @@
+package iter
+
+import "sort"
+
+// CountBy consumes the Iterator, counting its elements by the key computed by the given function.
+func CountBy[T any, K comparable](it Iterator[T], key func(T) K) map[K]uint {
+	m := make(map[K]uint)
+	drain(it, nil, func(v T) {
+		m[key(v)]++
+	})
+	return m
+}
+
+// Counter is a multiset, counting how often each key occurs.
+//
+// Keys are kept in the order of their first occurrence, which breaks ties in
+// MostCommon and is the order of Iter. Keys whose count drops to 0 are
+// removed. The zero value is an empty Counter ready to use.
+type Counter[K comparable] struct {
+	counts map[K]uint
+	seen   map[K]uint64
+	next   uint64
+}
+
+// NewCounter creates a Counter of the elements of the Iterator, consuming it.
+func NewCounter[K comparable](it Iterator[K]) *Counter[K] {
+	c := &Counter[K]{}
+	drain(it, nil, func(k K) {
+		c.Add(k, 1)
+	})
+	return c
+}
+
+// Add increases the count of k by n.
+func (c *Counter[K]) Add(k K, n uint) {
+	if n == 0 {
+		return
+	}
+	if c.counts == nil {
+		c.counts = make(map[K]uint)
+		c.seen = make(map[K]uint64)
+	}
+	if _, ok := c.counts[k]; !ok {
+		c.seen[k] = c.next
+		c.next++
+	}
+	c.counts[k] += n
+}
+
+// Subtract decreases the count of k by n, removing k once its count drops to 0.
+func (c *Counter[K]) Subtract(k K, n uint) {
+	count, ok := c.counts[k]
+	if !ok {
+		return
+	}
+	if n < count {
+		c.counts[k] = count - n
+		return
+	}
+	delete(c.counts, k)
+	delete(c.seen, k)
+}
+
+// Get returns the count of k, which is 0 if k is not in the Counter.
+func (c *Counter[K]) Get(k K) uint {
+	return c.counts[k]
+}
+
+// Len returns the number of distinct keys in the Counter.
+func (c *Counter[K]) Len() int {
+	return len(c.counts)
+}
+
+// MostCommon returns the n keys with the highest counts, in descending order of their counts.
+//
+// If the Counter has fewer than n keys, all of them are returned.
+func (c *Counter[K]) MostCommon(n uint) []Pair[K, uint] {
+	pairs := c.pairs()
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Y > pairs[j].Y })
+	if uint(len(pairs)) > n {
+		pairs = pairs[:n]
+	}
+	return pairs
+}
+
+// Iter creates an Iterator of Pairs of the keys and their counts, in the order of the keys' first occurrence.
+//
+// The Iterator works on a snapshot, later changes of the Counter are not reflected.
+func (c *Counter[K]) Iter() Iterator[Pair[K, uint]] {
+	return FromSlice(c.pairs())
+}
+
+// pairs returns the keys and their counts in the order of the keys' first occurrence.
+func (c *Counter[K]) pairs() []Pair[K, uint] {
+	pairs := make([]Pair[K, uint], 0, len(c.counts))
+	for k, count := range c.counts {
+		pairs = append(pairs, Pair[K, uint]{X: k, Y: count})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return c.seen[pairs[i].X] < c.seen[pairs[j].X] })
+	return pairs
+}
@@ -0,0 +1,52 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCountBy(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "cherry", "blueberry", "apricot"}
+	m := CountBy(FromSlice(words), func(s string) byte { return s[0] })
+	if len(m) != 3 || m['a'] != 3 || m['b'] != 2 || m['c'] != 1 {
+		t.Errorf("CountBy did not work\nm: %v\n", m)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := NewCounter(FromSlice(strings.Fields("b a c a b a d")))
+	if c.Len() != 4 || c.Get("a") != 3 || c.Get("b") != 2 || c.Get("x") != 0 {
+		t.Errorf("NewCounter did not work\nc: %v\n", c.Iter().Collect())
+	}
+	if v := fmt.Sprint(c.Iter().Collect()); v != "[{b 2} {a 3} {c 1} {d 1}]" {
+		t.Errorf("Iter did not keep the order of first occurrence\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(c.MostCommon(3)); v != "[{a 3} {b 2} {c 1}]" {
+		t.Errorf("MostCommon did not work\nmost common: %s\n", v)
+	}
+	if v := c.MostCommon(10); len(v) != 4 {
+		t.Errorf("MostCommon did not return all keys\nmost common: %v\n", v)
+	}
+
+	c.Subtract("a", 2)
+	c.Subtract("c", 5)
+	c.Subtract("x", 1)
+	c.Add("d", 3)
+	c.Add("e", 0)
+	if v := fmt.Sprint(c.Iter().Collect()); v != "[{b 2} {a 1} {d 4}]" {
+		t.Errorf("Add and Subtract did not work\nit: %s\n", v)
+	}
+
+	c.Add("c", 1)
+	if v := fmt.Sprint(c.MostCommon(4)); v != "[{d 4} {b 2} {a 1} {c 1}]" {
+		t.Errorf("removed key did not lose its position\nmost common: %s\n", v)
+	}
+
+	var zero Counter[int]
+	zero.Subtract(1, 1)
+	zero.Add(1, 2)
+	if zero.Get(1) != 2 || zero.Len() != 1 {
+		t.Errorf("zero Counter did not work\nc: %v\n", zero.Iter().Collect())
+	}
+}
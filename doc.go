@@ -7,6 +7,28 @@
 // using the provided methods on the iterators, one can define a pipeline that
 // automatically uses multiple threads.
 //
+// Consecutive adapters that work on one element at a time, like Filter, Map,
+// Inspect, Dedup and Unique, are fused into the Goroutine producing their
+// input, so such a chain only hands every element over a channel once. When
+// an Iterator is drained by this package, e.g. by Collect, Count, Fold or
+// ToMap, elements are handed over in batches of up to 64 where possible. The
+// Goroutine producing it, including the functions fused into it, may then run
+// ahead of the consumer by up to two batches. Operations that can stop early,
+// like Take, Find or Any, and all other adapters receive one element at a
+// time, so their input only runs ahead by the element waiting to be sent.
+//
+// Invalid arguments, like a step of 0 for StepBy or a non-positive interval
+// for Tick, are programming errors. They cause a panic with a message naming
+// the operation, as documented for each of them.
+//
+// Custom sources, like database cursors, implement Source. FromSource converts
+// them into an Iterator at the cost of a Goroutine, after which the adapters of
+// this package can be used with them. pull.FromSource reads them without one.
+//
+// Handing every element over a channel has a cost that dominates pipelines of
+// cheap operations. Package github.com/rohrschacht/iter/pull offers the same
+// operations implemented as plain functions without Goroutines.
+//
 // # Examples
 //
 //	it := iter.FromSlice([]int{1, 2, 3, 4, 5, 6}).
@@ -1,6 +1,13 @@
 package iter
 
-import "fmt"
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing/fstest"
+	"time"
+)
 
 func Example() {
 	it := FromSlice([]int{1, 2, 3, 4, 5, 6}).
@@ -59,6 +66,30 @@ func ExampleFromMapValues() {
 	fmt.Println(it.Collect())
 }
 
+func ExampleFromMapSorted() {
+	m := map[int]string{3: "3", 1: "1", 2: "2"}
+	it := FromMapSorted(m)
+	fmt.Println(it.Collect())
+	// output:
+	// [{1 1} {2 2} {3 3}]
+}
+
+func ExampleFromMapKeysSorted() {
+	m := map[int]string{3: "3", 1: "1", 2: "2"}
+	it := FromMapKeysSorted(m)
+	fmt.Println(it.Collect())
+	// output:
+	// [1 2 3]
+}
+
+func ExampleFromMapValuesSorted() {
+	m := map[int]string{3: "c", 1: "a", 2: "b"}
+	it := FromMapValuesSorted(m)
+	fmt.Println(it.Collect())
+	// output:
+	// [a b c]
+}
+
 func ExampleIterator_Filter() {
 	it := FromSlice([]int{1, 2, 3, 4, 5, 6})
 	filteredIter := it.Filter(func(i int) bool { return i%2 == 0 })
@@ -330,3 +361,282 @@ func ExampleIterator_Join() {
 	// output:
 	// 1,2,3,4
 }
+
+func ExampleFromJSONLines() {
+	input := "{\"name\":\"alice\"}\n{\"name\":\"bob\"}\nnot json\n"
+	type user struct {
+		Name string `json:"name"`
+	}
+	FromJSONLines[user](strings.NewReader(input)).ForEach(func(p Pair[user, error]) {
+		if p.Y != nil {
+			fmt.Println("error:", p.Y)
+			return
+		}
+		fmt.Println(p.X.Name)
+	})
+	// output:
+	// alice
+	// bob
+	// error: line 3: invalid character 'o' in literal null (expecting 'u')
+}
+
+func ExampleIterator_WriteJSONLines() {
+	it := FromSlice([]map[string]int{{"a": 1}, {"b": 2}})
+	if err := it.WriteJSONLines(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// output:
+	// {"a":1}
+	// {"b":2}
+}
+
+func ExampleFromJSONArray() {
+	input := `[{"name": "alice"}, {"name": "bob"}]`
+	type user struct {
+		Name string `json:"name"`
+	}
+	it := FromJSONArray[user](strings.NewReader(input))
+	for p := range it {
+		if p.Y != nil {
+			fmt.Println("error:", p.Y)
+			break
+		}
+		fmt.Println(p.X.Name)
+	}
+	// output:
+	// alice
+	// bob
+}
+
+func ExampleWalkFS() {
+	fsys := fstest.MapFS{
+		"main.go":          {Data: []byte("package main")},
+		"README.md":        {Data: []byte("# readme")},
+		"lib/lib.go":       {Data: []byte("package lib")},
+		"testdata/data.go": {Data: []byte("package data")},
+	}
+	it := WalkFS(fsys, ".",
+		SkipDirs(func(path string, d fs.DirEntry) bool { return d.Name() == "testdata" }),
+		MatchGlob("*.go"))
+	it.ForEach(func(e WalkEntry) { fmt.Println(e.Path) })
+	// output:
+	// lib/lib.go
+	// main.go
+}
+
+func ExampleIterator_Close() {
+	it := FromSlice([]int{1, 2, 3, 4, 5, 6}).
+		Map(func(x int) int { return x * x })
+	fmt.Println(<-it)
+	fmt.Println(<-it)
+	it.Close()
+	// output:
+	// 1
+	// 4
+}
+
+func ExampleTick() {
+	ticks := Tick(time.Millisecond)
+	fmt.Println(ticks.Take(3).Count())
+	ticks.Close()
+	// output:
+	// 3
+}
+
+func ExampleFromFunc() {
+	i := 0
+	it := FromFunc(func() (int, bool) {
+		i++
+		return i * i, i <= 4
+	})
+	fmt.Println(it.Collect())
+	// output:
+	// [1 4 9 16]
+}
+
+type countdown int
+
+func (c *countdown) Next() (int, bool) {
+	if *c == 0 {
+		return 0, false
+	}
+	*c--
+	return int(*c) + 1, true
+}
+
+func ExampleFromSource() {
+	c := countdown(3)
+	fmt.Println(FromSource[int](&c).Collect())
+	// output:
+	// [3 2 1]
+}
+
+func ExamplePeekable() {
+	// Group consecutive digits into numbers.
+	p := FromSlice([]rune("12+345+6")).Peekable()
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	for {
+		n := 0
+		for r, ok := p.NextIf(isDigit); ok; r, ok = p.NextIf(isDigit) {
+			n = n*10 + int(r-'0')
+		}
+		fmt.Println(n)
+		if _, ok := NextIfEq(p, '+'); !ok {
+			break
+		}
+	}
+	// output:
+	// 12
+	// 345
+	// 6
+}
+
+func ExampleIterator_FindOption() {
+	it := FromSlice([]int{1, 3, 4, 5})
+	even := it.FindOption(func(x int) bool { return x%2 == 0 })
+	if v, ok := even.Get(); ok {
+		fmt.Println(v)
+	}
+	fmt.Println(FromSlice([]int{}).LastOption().OrElse(-1))
+	// output:
+	// 4
+	// -1
+}
+
+func ExampleToMap() {
+	words := FromSlice([]string{"apple", "avocado", "banana"})
+	count := ToMap(words,
+		func(s string) byte { return s[0] },
+		func(string) int { return 1 },
+		func(_ byte, a, b int) int { return a + b })
+	fmt.Println(count['a'], count['b'])
+	// output:
+	// 2 1
+}
+
+func ExampleCollectPairs() {
+	m := CollectPairs(FromMap(map[string]int{"a": 1, "b": 2}).
+		Filter(func(p Pair[string, int]) bool { return p.Y > 1 }))
+	fmt.Println(m)
+	// output:
+	// map[b:2]
+}
+
+func ExampleGroupByKey() {
+	words := FromSlice([]string{"go", "rust", "c", "zig", "java"})
+	byLength := GroupByKey(words, func(s string) int { return len(s) })
+	fmt.Println(byLength[4])
+	// output:
+	// [rust java]
+}
+
+func ExampleGroupByKeyOrdered() {
+	words := FromSlice([]string{"go", "rust", "c", "zig", "java"})
+	for _, group := range GroupByKeyOrdered(words, func(s string) int { return len(s) }) {
+		fmt.Println(group.X, group.Y)
+	}
+	// output:
+	// 2 [go]
+	// 4 [rust java]
+	// 1 [c]
+	// 3 [zig]
+}
+
+func ExampleChunkBy() {
+	type entry struct {
+		request int
+		msg     string
+	}
+	log := FromSlice([]entry{{1, "start"}, {1, "done"}, {2, "start"}, {2, "failed"}, {3, "start"}})
+	for run := range ChunkBy(log, func(e entry) int { return e.request }) {
+		fmt.Println(run.X, len(run.Y))
+	}
+	// output:
+	// 1 2
+	// 2 2
+	// 3 1
+}
+
+func ExampleCounter() {
+	words := FromSlice(strings.Fields("the cat and the dog and the bird"))
+	c := NewCounter(words)
+	fmt.Println(c.MostCommon(2))
+	// output:
+	// [{the 3} {and 2}]
+}
+
+func ExampleMinMax() {
+	temperatures := FromSlice([]float64{12.5, 9.1, 17.8, 14.2})
+	if mm, ok := MinMax(temperatures).Get(); ok {
+		fmt.Println(mm.X, mm.Y)
+	}
+	// output:
+	// 9.1 17.8
+}
+
+func ExampleMaxByKey() {
+	words := FromSlice([]string{"go", "rust", "zig", "java"})
+	fmt.Println(MaxByKey(words, func(s string) int { return len(s) }))
+	// output:
+	// Some(rust)
+}
+
+func ExampleMean() {
+	ratings := FromSlice([]int{4, 5, 3, 5})
+	fmt.Println(Mean(ratings).OrElse(0))
+	// output:
+	// 4.25
+}
+
+func ExampleSumChecked() {
+	_, err := SumChecked(FromSlice([]uint8{200, 100}))
+	fmt.Println(err)
+	// output:
+	// iter: integer overflow
+}
+
+func ExampleStats() {
+	latencies := FromSlice([]int{12, 15, 11, 80, 14, 13, 12, 16, 13, 14})
+	s := Stats(latencies)
+	fmt.Printf("n=%d mean=%.1f min=%.0f max=%.0f median=%.1f\n", s.Count, s.Mean, s.Min, s.Max, s.Median())
+	// output:
+	// n=10 mean=20.0 min=11 max=80 median=13.5
+}
+
+func ExampleFoldInto() {
+	type order struct {
+		item  string
+		price int
+	}
+	orders := FromSlice([]order{{"tea", 3}, {"cake", 5}, {"tea", 3}})
+	var b strings.Builder
+	FoldInto(orders, &b, func(b *strings.Builder, o order) *strings.Builder {
+		fmt.Fprintf(b, "%s:%d ", o.item, o.price)
+		return b
+	})
+	fmt.Println(strings.TrimSpace(b.String()))
+	// output:
+	// tea:3 cake:5 tea:3
+}
+
+func ExampleScan() {
+	deposits := FromSlice([]int{100, -30, 50, -20})
+	fmt.Println(Scan(deposits, 0, func(balance, d int) int { return balance + d }).Collect())
+	// output:
+	// [100 70 120 100]
+}
+
+func ExampleSortedBy() {
+	type file struct {
+		name string
+		size int
+	}
+	files := FromSlice([]file{{"b.txt", 300}, {"a.txt", 100}, {"c.txt", 200}})
+	for f := range SortedBy(files, func(f file) int { return f.size }) {
+		fmt.Println(f.name, f.size)
+	}
+	// output:
+	// a.txt 100
+	// c.txt 200
+	// b.txt 300
+}
@@ -0,0 +1,206 @@
+package iter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// followInterval is the interval in which Follow checks the file for changes.
+const followInterval = 250 * time.Millisecond
+
+// Follow creates an Iterator over the lines appended to the file at path, like tail -F.
+//
+// Following starts at the current end of the file. If the file does not
+// exist yet, Follow waits for it to be created and reads it from the start.
+// When the file is truncated, reading restarts at its beginning. When it is
+// replaced, e.g. by a log rotation renaming it, the rest of the old file is
+// read before switching to the new one. Lines are produced without their line
+// ending. The Iterator ends when ctx is cancelled or the Iterator is closed.
+//
+// Every element is a Pair of a line and an error. Errors accessing the file,
+// other than it not existing, are produced as Pairs with an empty line. Follow
+// keeps retrying after an error and reports it again only once it changes.
+func Follow(ctx context.Context, path string) Iterator[Pair[string, error]] {
+	return FollowWithClock(ctx, SystemClock, path)
+}
+
+// FollowWithClock works like Follow, using the given Clock to schedule checking the file for changes.
+func FollowWithClock(ctx context.Context, c Clock, path string) Iterator[Pair[string, error]] {
+	f := &follower{path: path}
+	f.open(true)
+	ticker := c.NewTicker(followInterval)
+	return produce(func(e *emitter[Pair[string, error]]) {
+		defer f.close()
+		defer ticker.Stop()
+		for {
+			lines, err := f.poll()
+			for _, line := range lines {
+				if !e.sendUntil(Pair[string, error]{X: line}, ctx.Done()) {
+					return
+				}
+			}
+			if err != nil {
+				if !e.sendUntil(Pair[string, error]{Y: err}, ctx.Done()) {
+					return
+				}
+			}
+			select {
+			case <-ticker.C():
+			case <-e.done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// follower keeps track of the file read by Follow.
+type follower struct {
+	path     string
+	file     *os.File
+	info     os.FileInfo
+	offset   int64
+	partial  []byte
+	err      error
+	reported string
+}
+
+// open opens the file at path. A missing file is not an error, it leaves f.file nil.
+func (f *follower) open(atEnd bool) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			f.fail(err)
+		}
+		return
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		f.fail(err)
+		return
+	}
+	offset := int64(0)
+	if atEnd {
+		offset, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			file.Close()
+			f.fail(err)
+			return
+		}
+	}
+	f.file, f.info, f.offset = file, info, offset
+}
+
+func (f *follower) close() {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+}
+
+// fail records the first error of the current poll.
+func (f *follower) fail(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// poll returns all complete lines that have been written since the last call.
+//
+// The error is only returned if it differs from the one returned by the previous call.
+func (f *follower) poll() ([]string, error) {
+	lines := f.readAll()
+
+	err := f.err
+	f.err = nil
+	if err == nil {
+		f.reported = ""
+		return lines, nil
+	}
+	if err.Error() == f.reported {
+		return lines, nil
+	}
+	f.reported = err.Error()
+	return lines, err
+}
+
+func (f *follower) readAll() []string {
+	if f.file == nil {
+		f.open(false)
+		if f.file == nil {
+			return nil
+		}
+	}
+	lines := f.read()
+
+	info, err := f.file.Stat()
+	if err != nil {
+		f.fail(err)
+	} else if info.Size() < f.offset {
+		lines = append(lines, f.flush()...)
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			f.fail(err)
+		} else {
+			f.offset = 0
+			lines = append(lines, f.read()...)
+		}
+	}
+
+	info, err = os.Stat(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		f.fail(err)
+	} else if err == nil && !os.SameFile(info, f.info) {
+		lines = append(lines, f.flush()...)
+		f.close()
+		f.open(false)
+		if f.file != nil {
+			lines = append(lines, f.read()...)
+		}
+	}
+	return lines
+}
+
+// read reads the file up to its current end and splits the data into lines.
+func (f *follower) read() []string {
+	var lines []string
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := f.file.Read(buf)
+		f.offset += int64(n)
+		data := buf[:n]
+		for {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				break
+			}
+			line := append(f.partial, data[:i]...)
+			lines = append(lines, string(bytes.TrimSuffix(line, []byte{'\r'})))
+			f.partial = f.partial[:0]
+			data = data[i+1:]
+		}
+		f.partial = append(f.partial, data...)
+		if err != nil && err != io.EOF {
+			f.fail(err)
+		}
+		if err != nil || n == 0 {
+			return lines
+		}
+	}
+}
+
+// flush returns the incomplete last line, if there is one.
+func (f *follower) flush() []string {
+	if len(f.partial) == 0 {
+		return nil
+	}
+	line := string(bytes.TrimSuffix(f.partial, []byte{'\r'}))
+	f.partial = f.partial[:0]
+	return []string{line}
+}
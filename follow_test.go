@@ -0,0 +1,125 @@
+package iter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectLines(t *testing.T, it Iterator[Pair[string, error]], expected ...string) {
+	t.Helper()
+	for _, e := range expected {
+		select {
+		case line, ok := <-it:
+			if !ok {
+				t.Fatalf("Follow ended early, expected: %q", e)
+			}
+			if line.Y != nil || line.X != e {
+				t.Fatalf("Follow did not work\nline: %q\nerr: %v\nexpected: %q", line.X, line.Y, e)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Follow timed out, expected: %q", e)
+		}
+	}
+}
+
+func TestFollowWithClock(t *testing.T) {
+	clock := newFakeClock()
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old line\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := FollowWithClock(ctx, clock, path)
+
+	appendFile(t, path, "first\nsecond\n")
+	clock.Advance(followInterval)
+	expectLines(t, it, "first", "second")
+
+	appendFile(t, path, "par")
+	clock.Advance(followInterval)
+	appendFile(t, path, "tial\r\n")
+	clock.Advance(followInterval)
+	expectLines(t, it, "partial")
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path+".1", "last of old\n")
+	appendFile(t, path, "rotated\n")
+	clock.Advance(followInterval)
+	expectLines(t, it, "last of old", "rotated")
+
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "new\n")
+	clock.Advance(followInterval)
+	expectLines(t, it, "new")
+
+	cancel()
+	select {
+	case _, ok := <-it:
+		if ok {
+			t.Error("Follow did not stop after cancellation")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Follow did not stop after cancellation")
+	}
+	select {
+	case <-clock.tickers[0].stopped:
+	case <-time.After(2 * time.Second):
+		t.Error("Follow did not stop its ticker")
+	}
+}
+
+func TestFollowMissingFile(t *testing.T) {
+	clock := newFakeClock()
+	path := filepath.Join(t.TempDir(), "app.log")
+	it := FollowWithClock(context.Background(), clock, path)
+	defer it.Close()
+
+	appendFile(t, path, "created\n")
+	clock.Advance(followInterval)
+	expectLines(t, it, "created")
+}
+
+func TestFollowError(t *testing.T) {
+	it := FollowWithClock(context.Background(), newFakeClock(), t.TempDir())
+	defer it.Close()
+	select {
+	case p := <-it:
+		if p.Y == nil {
+			t.Errorf("Follow did not report an error for a directory\nline: %q\n", p.X)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Follow did not report an error for a directory")
+	}
+}
+
+func TestFollower_PollReportsOnce(t *testing.T) {
+	f := &follower{path: t.TempDir()}
+	defer f.close()
+	f.open(false)
+	if _, err := f.poll(); err == nil {
+		t.Error("poll did not report an error for a directory")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := f.poll(); err != nil {
+			t.Errorf("poll reported the same error again\nerr: %v\n", err)
+		}
+	}
+}
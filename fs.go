@@ -0,0 +1,91 @@
+package iter
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// WalkEntry is a file or directory visited by WalkFS.
+//
+// If visiting the entry failed, Err holds the error. In that case Entry may be nil.
+type WalkEntry struct {
+	Path  string
+	Entry fs.DirEntry
+	Err   error
+}
+
+// WalkOption configures the behaviour of WalkFS.
+type WalkOption func(*walkConfig)
+
+// errWalkClosed aborts the walk of WalkFS once its Iterator was closed.
+var errWalkClosed = errors.New("iter: walk closed")
+
+type walkConfig struct {
+	skipDir func(string, fs.DirEntry) bool
+	pattern string
+}
+
+// SkipDirs makes WalkFS skip every directory for which f returns true, including its contents.
+func SkipDirs(f func(path string, d fs.DirEntry) bool) WalkOption {
+	return func(c *walkConfig) {
+		c.skipDir = f
+	}
+}
+
+// MatchGlob makes WalkFS only produce entries matching the given pattern.
+//
+// The pattern uses the syntax of path.Match. It is matched against the base
+// name of each entry, unless it contains a slash, in which case it is matched
+// against the whole path. Directories that do not match are still descended into.
+func MatchGlob(pattern string) WalkOption {
+	return func(c *walkConfig) {
+		c.pattern = pattern
+	}
+}
+
+// WalkFS creates an Iterator over the file tree of fsys rooted at root.
+//
+// The tree is walked lazily in lexical order as described by fs.WalkDir, so no
+// more of it is read than the consumer asks for. Errors are produced as
+// entries with Err set and do not end the walk.
+func WalkFS(fsys fs.FS, root string, opts ...WalkOption) Iterator[WalkEntry] {
+	var c walkConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return produce(func(e *emitter[WalkEntry]) {
+		if _, err := path.Match(c.pattern, ""); err != nil {
+			e.send(WalkEntry{Path: root, Err: err})
+			return
+		}
+		fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if !e.send(WalkEntry{Path: p, Entry: d, Err: err}) {
+					return errWalkClosed
+				}
+				return nil
+			}
+			if d.IsDir() && c.skipDir != nil && c.skipDir(p, d) {
+				return fs.SkipDir
+			}
+			if c.pattern != "" && !c.matches(p) {
+				return nil
+			}
+			if !e.send(WalkEntry{Path: p, Entry: d}) {
+				return errWalkClosed
+			}
+			return nil
+		})
+	})
+}
+
+func (c *walkConfig) matches(p string) bool {
+	name := p
+	if !strings.Contains(c.pattern, "/") {
+		name = path.Base(p)
+	}
+	ok, _ := path.Match(c.pattern, name)
+	return ok
+}
@@ -0,0 +1,87 @@
+package iter
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+var testFS = fstest.MapFS{
+	"a.go":             {Data: []byte("package a")},
+	"b.txt":            {Data: []byte("b")},
+	"sub/c.go":         {Data: []byte("package c")},
+	"sub/d.txt":        {Data: []byte("d")},
+	"vendor/x/e.go":    {Data: []byte("package e")},
+	"sub/deeper/f.go":  {Data: []byte("package f")},
+	"sub/deeper/g.md":  {Data: []byte("g")},
+	"vendor/x/h.txt":   {Data: []byte("h")},
+	"vendor/README.md": {Data: []byte("readme")},
+}
+
+func walkPaths(it Iterator[WalkEntry]) ([]string, []error) {
+	var paths []string
+	var errs []error
+	for e := range it {
+		if e.Err != nil {
+			errs = append(errs, e.Err)
+			continue
+		}
+		paths = append(paths, e.Path)
+	}
+	return paths, errs
+}
+
+func TestWalkFS(t *testing.T) {
+	paths, errs := walkPaths(WalkFS(testFS, "."))
+	expected := []string{".", "a.go", "b.txt", "sub", "sub/c.go", "sub/d.txt", "sub/deeper", "sub/deeper/f.go",
+		"sub/deeper/g.md", "vendor", "vendor/README.md", "vendor/x", "vendor/x/e.go", "vendor/x/h.txt"}
+	if len(errs) != 0 {
+		t.Errorf("WalkFS returned errors: %v", errs)
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("WalkFS did not work\nit: %v\nexpected: %v\n", paths, expected)
+		return
+	}
+	for i := 0; i < len(expected); i++ {
+		if paths[i] != expected[i] {
+			t.Errorf("WalkFS did not work\nit: %v\nexpected: %v\n", paths, expected)
+			return
+		}
+	}
+}
+
+func TestWalkFSOptions(t *testing.T) {
+	skipVendor := SkipDirs(func(p string, d fs.DirEntry) bool { return d.Name() == "vendor" })
+	paths, errs := walkPaths(WalkFS(testFS, ".", skipVendor, MatchGlob("*.go")))
+	expected := []string{"a.go", "sub/c.go", "sub/deeper/f.go"}
+	if len(errs) != 0 {
+		t.Errorf("WalkFS returned errors: %v", errs)
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("WalkFS did not work with options\nit: %v\nexpected: %v\n", paths, expected)
+		return
+	}
+	for i := 0; i < len(expected); i++ {
+		if paths[i] != expected[i] {
+			t.Errorf("WalkFS did not work with options\nit: %v\nexpected: %v\n", paths, expected)
+			return
+		}
+	}
+
+	paths, _ = walkPaths(WalkFS(testFS, ".", MatchGlob("sub/*/*")))
+	if len(paths) != 2 || paths[0] != "sub/deeper/f.go" || paths[1] != "sub/deeper/g.md" {
+		t.Errorf("WalkFS did not match whole paths\nit: %v\n", paths)
+	}
+}
+
+func TestWalkFSError(t *testing.T) {
+	_, errs := walkPaths(WalkFS(testFS, "missing"))
+	if len(errs) != 1 {
+		t.Errorf("WalkFS did not report a missing root\nerrs: %v\n", errs)
+	}
+
+	_, errs = walkPaths(WalkFS(testFS, ".", MatchGlob("[")))
+	if len(errs) != 1 {
+		t.Errorf("WalkFS did not report a bad pattern\nerrs: %v\n", errs)
+	}
+}
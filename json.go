@@ -0,0 +1,131 @@
+package iter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// LineError reports an error that occurred while processing a line of JSON Lines data.
+type LineError struct {
+	Line uint
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ElementError reports an error that occurred while decoding an element of a JSON array.
+//
+// Index is the position of the element in the array, starting at 0.
+type ElementError struct {
+	Index uint
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// FromJSONLines creates an Iterator that decodes one JSON value per line of r into T.
+//
+// Every element is a Pair of the decoded value and an error. If a line cannot
+// be decoded, its Pair contains a *LineError holding the line number and the
+// decoding continues with the next line. Empty lines are skipped. If reading
+// from r fails, a last Pair containing the error is produced instead of the
+// line being read.
+func FromJSONLines[T any](r io.Reader) Iterator[Pair[T, error]] {
+	return produce(func(e *emitter[Pair[T, error]]) {
+		br := bufio.NewReader(r)
+		line := uint(0)
+		for {
+			b, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				// A partial line is not decoded, as its rest could not be read.
+				var v T
+				e.send(Pair[T, error]{X: v, Y: &LineError{Line: line + 1, Err: err}})
+				return
+			}
+			if len(b) > 0 {
+				line++
+				b = bytes.TrimSpace(b)
+				if len(b) > 0 {
+					var v T
+					if err := json.Unmarshal(b, &v); err != nil {
+						if !e.send(Pair[T, error]{X: v, Y: &LineError{Line: line, Err: err}}) {
+							return
+						}
+					} else if !e.send(Pair[T, error]{X: v}) {
+						return
+					}
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+		}
+	})
+}
+
+// WriteJSONLines consumes the Iterator, writing every element as one line of JSON to w.
+//
+// If an element cannot be encoded or written, a *LineError holding the line
+// number is returned and the rest of the Iterator is left unconsumed.
+func (it Iterator[T]) WriteJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	line := uint(0)
+	for v := range it {
+		line++
+		if err := enc.Encode(v); err != nil {
+			return &LineError{Line: line, Err: err}
+		}
+	}
+	return nil
+}
+
+// FromJSONArray creates an Iterator over the elements of a top-level JSON array read from r.
+//
+// The elements are decoded into T one at a time, so the array is never held in
+// memory as a whole. Every element is a Pair of the decoded value and an error.
+// If an element cannot be decoded, its Pair contains an *ElementError holding
+// the element's index. Since the decoder cannot resynchronize after malformed
+// input, the first error ends the Iterator.
+func FromJSONArray[T any](r io.Reader) Iterator[Pair[T, error]] {
+	return produce(func(e *emitter[Pair[T, error]]) {
+		var zero T
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			e.send(Pair[T, error]{X: zero, Y: err})
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			e.send(Pair[T, error]{X: zero, Y: fmt.Errorf("expected JSON array, got %v", tok)})
+			return
+		}
+		for i := uint(0); dec.More(); i++ {
+			var v T
+			if err := dec.Decode(&v); err != nil {
+				e.send(Pair[T, error]{X: v, Y: &ElementError{Index: i, Err: err}})
+				return
+			}
+			if !e.send(Pair[T, error]{X: v}) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			e.send(Pair[T, error]{X: zero, Y: err})
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package iter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type jsonRecord struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestFromJSONLines(t *testing.T) {
+	input := "{\"name\":\"alice\",\"age\":30}\n\n{\"name\":\"bob\",\"age\":25}\n{\"name\":\"carol\",\"age\":41}"
+	it := FromJSONLines[jsonRecord](strings.NewReader(input)).Collect()
+	expected := []jsonRecord{{Name: "alice", Age: 30}, {Name: "bob", Age: 25}, {Name: "carol", Age: 41}}
+	if len(it) != len(expected) {
+		t.Errorf("FromJSONLines did not work\nit: %v\nexpected: %v\n", it, expected)
+		return
+	}
+	for i := 0; i < len(expected); i++ {
+		if it[i].Y != nil || it[i].X != expected[i] {
+			t.Errorf("FromJSONLines did not work\nit: %v\nexpected: %v\n", it, expected)
+			return
+		}
+	}
+}
+
+func TestFromJSONLinesError(t *testing.T) {
+	input := "1\n2\nthree\n4\n"
+	it := FromJSONLines[int](strings.NewReader(input)).Collect()
+	if len(it) != 4 {
+		t.Errorf("FromJSONLines did not continue after an error\nit: %v\n", it)
+		return
+	}
+	var lineErr *LineError
+	if !errors.As(it[2].Y, &lineErr) || lineErr.Line != 3 {
+		t.Errorf("FromJSONLines did not report the line number\nerr: %v\n", it[2].Y)
+	}
+	if it[3].Y != nil || it[3].X != 4 {
+		t.Errorf("FromJSONLines did not work after an error\nit: %v\n", it[3])
+	}
+}
+
+func TestIterator_WriteJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	it := FromSlice([]jsonRecord{{Name: "alice", Age: 30}, {Name: "bob", Age: 25}})
+	if err := it.WriteJSONLines(&buf); err != nil {
+		t.Errorf("WriteJSONLines returned an error: %v", err)
+		return
+	}
+	expected := "{\"name\":\"alice\",\"age\":30}\n{\"name\":\"bob\",\"age\":25}\n"
+	if buf.String() != expected {
+		t.Errorf("WriteJSONLines did not work\nit: %q\nexpected: %q\n", buf.String(), expected)
+	}
+}
+
+func TestIterator_WriteJSONLinesError(t *testing.T) {
+	var buf bytes.Buffer
+	it := FromSlice([]any{1, 2, func() {}, 4})
+	err := it.WriteJSONLines(&buf)
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 {
+		t.Errorf("WriteJSONLines did not report the line number\nerr: %v\n", err)
+	}
+	if buf.String() != "1\n2\n" {
+		t.Errorf("WriteJSONLines wrote unexpected output: %q", buf.String())
+	}
+}
+
+func TestFromJSONArray(t *testing.T) {
+	input := `[{"name":"alice","age":30}, {"name":"bob","age":25},
+		{"name":"carol","age":41}]`
+	it := FromJSONArray[jsonRecord](strings.NewReader(input)).Collect()
+	expected := []jsonRecord{{Name: "alice", Age: 30}, {Name: "bob", Age: 25}, {Name: "carol", Age: 41}}
+	if len(it) != len(expected) {
+		t.Errorf("FromJSONArray did not work\nit: %v\nexpected: %v\n", it, expected)
+		return
+	}
+	for i := 0; i < len(expected); i++ {
+		if it[i].Y != nil || it[i].X != expected[i] {
+			t.Errorf("FromJSONArray did not work\nit: %v\nexpected: %v\n", it, expected)
+			return
+		}
+	}
+
+	empty := FromJSONArray[int](strings.NewReader("[]")).Collect()
+	if len(empty) != 0 {
+		t.Errorf("FromJSONArray did not work for an empty array\nit: %v\n", empty)
+	}
+}
+
+func TestFromJSONArrayError(t *testing.T) {
+	it := FromJSONArray[int](strings.NewReader(`{"a":1}`)).Collect()
+	if len(it) != 1 || it[0].Y == nil {
+		t.Errorf("FromJSONArray did not reject a non-array\nit: %v\n", it)
+	}
+
+	it = FromJSONArray[int](strings.NewReader(`[1, 2, "three", 4]`)).Collect()
+	if len(it) != 3 || it[0].X != 1 || it[1].X != 2 || it[2].Y == nil {
+		t.Errorf("FromJSONArray did not stop at the first error\nit: %v\n", it)
+		return
+	}
+	var elemErr *ElementError
+	if !errors.As(it[2].Y, &elemErr) || elemErr.Index != 2 {
+		t.Errorf("FromJSONArray did not report the element index\nerr: %v\n", it[2].Y)
+	}
+
+	it = FromJSONArray[int](strings.NewReader(`[1, 2`)).Collect()
+	if len(it) != 3 || it[2].Y == nil {
+		t.Errorf("FromJSONArray did not report a truncated array\nit: %v\n", it)
+	}
+}
+
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestFromJSONLinesReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	tests := []struct {
+		input string
+		line  uint
+	}{
+		{"1\n2\n", 3},
+		{"1\n2\n3", 3},
+	}
+	for _, test := range tests {
+		it := FromJSONLines[int](&failingReader{data: test.input, err: readErr}).Collect()
+		if len(it) != 3 || it[0] != (Pair[int, error]{X: 1}) || it[1] != (Pair[int, error]{X: 2}) {
+			t.Errorf("FromJSONLines did not stop at the read error\nit: %v\n", it)
+			continue
+		}
+		last := it[2]
+		var lineErr *LineError
+		if last.X != 0 || !errors.As(last.Y, &lineErr) || !errors.Is(last.Y, readErr) || lineErr.Line != test.line {
+			t.Errorf("FromJSONLines did not report the read error of line %d\nerr: %v\n", test.line, last.Y)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package iter
 
-import "fmt"
+import (
+	"fmt"
+)
 
 // Iterator can be used to process data in a pipeline pattern.
-type Iterator[T any] chan T
+//
+// An Iterator is a receive-only channel, so its consumers cannot send into or
+// close the channel of a stage.
+type Iterator[T any] <-chan T
 
 // Pair is used as a helper when an Iterator has to hold multiple values.
 type Pair[T, K any] struct {
@@ -12,103 +17,114 @@ type Pair[T, K any] struct {
 }
 
 // FromChan creates an Iterator from a channel.
-func FromChan[T any](c chan T) Iterator[T] {
+func FromChan[T any](c <-chan T) Iterator[T] {
 	return c
 }
 
+// Close stops the goroutines producing the Iterator.
+//
+// Closing propagates to all Iterators the Iterator reads from, so a whole
+// pipeline is torn down by closing its last Iterator. Elements that have not
+// been received yet are discarded and the Iterator ends shortly after.
+// Adapters and terminals that stop reading their input before its end, like
+// Take or Find, leave it open, so that the rest can still be consumed. Closing
+// the Iterator of such an adapter still closes its input, even after it ended.
+//
+// Close has no effect on Iterators that are not produced by this package,
+// such as channels passed to FromChan.
+func (it Iterator[T]) Close() {
+	if s := it.stage(); s != nil {
+		s.stop()
+	}
+}
+
+// FromFunc creates an Iterator over the elements returned by next.
+//
+// A new goroutine calls next until it returns false or the Iterator is closed.
+func FromFunc[T any](next func() (T, bool)) Iterator[T] {
+	return produce(func(e *emitter[T]) {
+		for v, ok := next(); ok; v, ok = next() {
+			if !e.send(v) {
+				return
+			}
+		}
+	})
+}
+
 // FromSlice creates an Iterator over the given slice.
 func FromSlice[T any](slice []T) Iterator[T] {
-	it := make(chan T)
-	go func() {
-		defer close(it)
+	return produceBatches(func(e *emitter[T]) {
 		for _, v := range slice {
-			it <- v
+			if !e.send(v) {
+				return
+			}
 		}
-	}()
-	return it
+	})
 }
 
 // FromMap creates an Iterator of Pairs that contain key and value of the given map.
 func FromMap[T comparable, K any](m map[T]K) Iterator[Pair[T, K]] {
-	it := make(chan Pair[T, K])
-	go func() {
-		defer close(it)
+	return produceBatches(func(e *emitter[Pair[T, K]]) {
 		for key, v := range m {
-			it <- Pair[T, K]{X: key, Y: v}
+			if !e.send(Pair[T, K]{X: key, Y: v}) {
+				return
+			}
 		}
-	}()
-	return it
+	})
 }
 
 // FromMapKeys creates an Iterator over the keys of the given map.
 func FromMapKeys[T comparable, K any](m map[T]K) Iterator[T] {
-	it := make(chan T)
-	go func() {
-		defer close(it)
+	return produceBatches(func(e *emitter[T]) {
 		for key := range m {
-			it <- key
+			if !e.send(key) {
+				return
+			}
 		}
-	}()
-	return it
+	})
 }
 
 // FromMapValues creates an Iterator over the values of the given map.
 func FromMapValues[K comparable, T any](m map[K]T) Iterator[T] {
-	it := make(chan T)
-	go func() {
-		defer close(it)
+	return produceBatches(func(e *emitter[T]) {
 		for _, v := range m {
-			it <- v
+			if !e.send(v) {
+				return
+			}
 		}
-	}()
-	return it
+	})
 }
 
 // Collect consumes the Iterator, returning a slice of all its elements.
 func (it Iterator[T]) Collect() []T {
 	var slice []T
-	for v := range it {
+	drain(it, nil, func(v T) {
 		slice = append(slice, v)
-	}
+	})
 	return slice
 }
 
 // Filter uses the given function to determine whether elements should continue through the pipeline.
 func (it Iterator[T]) Filter(f func(T) bool) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			if f(v) {
-				newIter <- v
-			}
-		}
-	}()
-	return newIter
+	return apply(it, func(v T) (T, bool) {
+		return v, f(v)
+	})
 }
 
 // Map applies the given function to all elements going through the pipeline.
 func (it Iterator[T]) Map(f func(T) T) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			newIter <- f(v)
-		}
-	}()
-	return newIter
+	return apply(it, func(v T) (T, bool) {
+		return f(v), true
+	})
 }
 
 // MapInto applies the given function to all elements and allows for the type to change.
 func MapInto[T, K any](it Iterator[T], f func(T) K) Iterator[K] {
-	newIter := make(chan K)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			newIter <- f(v)
-		}
-	}()
-	return newIter
+	return produceBatches(func(e *emitter[K]) {
+		forEach(it, e.flush, func(v T) bool {
+			return e.send(f(v))
+		})
+	}, it)
 }
 
 // Skip skips the first n elements of the Iterator.
@@ -125,70 +141,88 @@ func (it Iterator[T]) Skip(n uint) Iterator[T] {
 //
 // All elements after the first n elements will be discarded.
 func (it Iterator[T]) Take(n uint) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for i := uint(0); i < n; i++ {
-			v, more := <-it
-			if !more {
-				return
-			}
-			newIter <- v
+	return produceBatches(func(e *emitter[T]) {
+		if n == 0 {
+			return
 		}
-	}()
-	return newIter
+		i := uint(0)
+		forEach(it, e.flush, func(v T) bool {
+			i++
+			return e.send(v) && i < n
+		})
+	}, it)
 }
 
 // Nth returns a pointer to the element at position n.
 //
 // If there are fewer than n elements in the Iterator, nil is returned.
+// Positions start at 1, Nth panics if n is 0.
 func (it Iterator[T]) Nth(n uint) *T {
-	for i := uint(0); i < n-1; i++ {
-		_, ok := <-it
-		if !ok {
-			return nil
-		}
+	if n == 0 {
+		panic("iter: Nth called with n == 0")
 	}
-	v, ok := <-it
-	if !ok {
-		return nil
+	return it.NthOption(n).pointer()
+}
+
+// NthOption works like Nth, but returns an Option instead of a pointer.
+func (it Iterator[T]) NthOption(n uint) Option[T] {
+	if n == 0 {
+		panic("iter: NthOption called with n == 0")
 	}
-	return &v
+	i := uint(0)
+	var nth Option[T]
+	forEach(it, nil, func(v T) bool {
+		i++
+		if i == n {
+			nth = Some(v)
+		}
+		return !nth.ok
+	})
+	return nth
 }
 
 // Count consumes the Iterator and returns its number of elements.
 func (it Iterator[T]) Count() uint {
 	c := uint(0)
-	for range it {
+	drain(it, nil, func(T) {
 		c++
-	}
+	})
 	return c
 }
 
 // Last returns the last element of the Iterator, consuming it in the process.
+//
+// If the Iterator is empty, the zero value is returned. Use LastOption to tell
+// this apart from a last element that is the zero value.
 func (it Iterator[T]) Last() T {
-	var l T
-	for v := range it {
-		l = v
-	}
+	return it.LastOption().OrElse(*new(T))
+}
+
+// LastOption returns the last element of the Iterator, consuming it in the process.
+//
+// If the Iterator is empty, None is returned.
+func (it Iterator[T]) LastOption() Option[T] {
+	var l Option[T]
+	drain(it, nil, func(v T) {
+		l = Some(v)
+	})
 	return l
 }
 
 // StepBy advances the Iterator by n elements every time something is taken.
+//
+// StepBy panics if n is 0.
 func (it Iterator[T]) StepBy(n uint) Iterator[T] {
 	if n == 0 {
-		return nil
+		panic("iter: StepBy called with n == 0")
 	}
 
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[T]) {
 		for {
 			v, more := <-it
-			if !more {
+			if !more || !e.send(v) {
 				return
 			}
-			newIter <- v
 
 			for i := uint(0); i < n-1; i++ {
 				_, more := <-it
@@ -197,54 +231,44 @@ func (it Iterator[T]) StepBy(n uint) Iterator[T] {
 				}
 			}
 		}
-	}()
-	return newIter
+	}, it)
 }
 
 // Chain creates a new Iterator which returns the elements of both Iterators.
 func (it Iterator[T]) Chain(other Iterator[T]) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			newIter <- v
-		}
-		for v := range other {
-			newIter <- v
+	return produceBatches(func(e *emitter[T]) {
+		sent := true
+		forEach(it, e.flush, func(v T) bool {
+			sent = e.send(v)
+			return sent
+		})
+		if sent {
+			forEach(other, e.flush, e.send)
 		}
-	}()
-	return newIter
+	}, it, other)
 }
 
 // Intersperse inserts the separator sep between each element of the Iterator.
 func (it Iterator[T]) Intersperse(sep T) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[T]) {
 		v, more := <-it
-		if more {
-			newIter <- v
-		} else {
+		if !more || !e.send(v) {
 			return
 		}
 		for {
 			v, more := <-it
-			if more {
-				newIter <- sep
-				newIter <- v
-			} else {
+			if !more || !e.send(sep) || !e.send(v) {
 				return
 			}
 		}
-	}()
-	return newIter
+	}, it)
 }
 
 // ForEach executes the given function for each element of the Iterator.
 func (it Iterator[T]) ForEach(f func(T)) {
-	for v := range it {
+	drain(it, nil, func(v T) {
 		f(v)
-	}
+	})
 }
 
 // Zip creates a new Iterator that contains Pairs containing the elements of both Iterators.
@@ -252,9 +276,7 @@ func (it Iterator[T]) ForEach(f func(T)) {
 // If one of the input Iterators is shorter than the other one, the new Iterator
 // will stop at that point.
 func Zip[T, K any](it Iterator[T], other Iterator[K]) Iterator[Pair[T, K]] {
-	newIter := make(chan Pair[T, K])
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[Pair[T, K]]) {
 		for {
 			v1, ok1 := <-it
 			if !ok1 {
@@ -264,59 +286,44 @@ func Zip[T, K any](it Iterator[T], other Iterator[K]) Iterator[Pair[T, K]] {
 			if !ok2 {
 				return
 			}
-			newIter <- Pair[T, K]{X: v1, Y: v2}
+			if !e.send(Pair[T, K]{X: v1, Y: v2}) {
+				return
+			}
 		}
-	}()
-	return newIter
+	}, it, other)
 }
 
 // SkipWhile discards all elements until the condition of the given function is met once.
 func (it Iterator[T]) SkipWhile(f func(T) bool) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			if !f(v) {
-				newIter <- v
-				break
+	return produceBatches(func(e *emitter[T]) {
+		skipping := true
+		forEach(it, e.flush, func(v T) bool {
+			if skipping && f(v) {
+				return true
 			}
-		}
-		for v := range it {
-			newIter <- v
-		}
-	}()
-	return newIter
+			skipping = false
+			return e.send(v)
+		})
+	}, it)
 }
 
 // TakeWhile takes elements until the condition of the given function is false once.
 func (it Iterator[T]) TakeWhile(f func(T) bool) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			if f(v) {
-				newIter <- v
-			} else {
-				return
-			}
-		}
-	}()
-	return newIter
+	return produceBatches(func(e *emitter[T]) {
+		forEach(it, e.flush, func(v T) bool {
+			return f(v) && e.send(v)
+		})
+	}, it)
 }
 
 // Inspect applies the given function on each element while the Iterator is consumed.
 //
 // This is helpful for debugging, see the example.
 func (it Iterator[T]) Inspect(f func(T)) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		for v := range it {
-			f(v)
-			newIter <- v
-		}
-	}()
-	return newIter
+	return apply(it, func(v T) (T, bool) {
+		f(v)
+		return v, true
+	})
 }
 
 // Partition splits the contents of the iterator based on the condition defined in the given function.
@@ -327,125 +334,177 @@ func (it Iterator[T]) Inspect(f func(T)) Iterator[T] {
 func (it Iterator[T]) Partition(f func(T) bool) ([]T, []T) {
 	var yes []T
 	var no []T
-	for v := range it {
+	drain(it, nil, func(v T) {
 		if f(v) {
 			yes = append(yes, v)
 		} else {
 			no = append(no, v)
 		}
-	}
+	})
 	return yes, no
 }
 
 // Fold applies the given function to all elements, folding them into the given accumulator.
 func (it Iterator[T]) Fold(acc T, f func(T, T) T) T {
-	for v := range it {
+	drain(it, nil, func(v T) {
 		acc = f(acc, v)
-	}
+	})
+	return acc
+}
+
+// FoldInto works like Fold, but allows the accumulator to have a different type than the elements.
+func FoldInto[T, A any](it Iterator[T], acc A, f func(A, T) A) A {
+	drain(it, nil, func(v T) {
+		acc = f(acc, v)
+	})
 	return acc
 }
 
+// ReduceInto works like ReduceOption, but allows the accumulator to have a different type than the elements.
+//
+// The initial accumulator is created from the first element by init. If the
+// Iterator is empty, None is returned.
+func ReduceInto[T, A any](it Iterator[T], init func(T) A, f func(A, T) A) Option[A] {
+	var acc Option[A]
+	drain(it, nil, func(v T) {
+		if acc.ok {
+			acc.v = f(acc.v, v)
+		} else {
+			acc = Some(init(v))
+		}
+	})
+	return acc
+}
+
+// Scan works like FoldInto, but lazily produces every intermediate accumulator.
+//
+// For each element, f is called with the accumulator so far and the element,
+// and its result is produced and becomes the new accumulator. The initial
+// accumulator itself is not produced. Scan works on unbounded Iterators, e.g.
+// for running totals.
+func Scan[T, A any](it Iterator[T], acc A, f func(A, T) A) Iterator[A] {
+	return produceBatches(func(e *emitter[A]) {
+		forEach(it, e.flush, func(v T) bool {
+			acc = f(acc, v)
+			return e.send(acc)
+		})
+	}, it)
+}
+
 // Reduce folds the Iterator using the given function, using the first element as the initial accumulator.
 //
 // Reduce returns a pointer for the accumulated value. If the Iterator is empty, this will be nil.
 func (it Iterator[T]) Reduce(f func(T, T) T) *T {
-	acc, ok := <-it
-	if !ok {
-		return nil
-	}
-	for v := range it {
-		acc = f(acc, v)
-	}
-	return &acc
+	return it.ReduceOption(f).pointer()
+}
+
+// ReduceOption works like Reduce, but returns an Option instead of a pointer.
+func (it Iterator[T]) ReduceOption(f func(T, T) T) Option[T] {
+	var acc Option[T]
+	drain(it, nil, func(v T) {
+		if acc.ok {
+			acc.v = f(acc.v, v)
+		} else {
+			acc = Some(v)
+		}
+	})
+	return acc
 }
 
 // All checks whether the given condition is true for all elements.
 func (it Iterator[T]) All(f func(T) bool) bool {
-	for v := range it {
-		if !f(v) {
-			return false
-		}
-	}
-	return true
+	all := true
+	forEach(it, nil, func(v T) bool {
+		all = f(v)
+		return all
+	})
+	return all
 }
 
 // Any checks whether there exists one element for which the given condition is true.
 func (it Iterator[T]) Any(f func(T) bool) bool {
-	for v := range it {
-		if f(v) {
-			return true
-		}
-	}
-	return false
+	found := false
+	forEach(it, nil, func(v T) bool {
+		found = f(v)
+		return !found
+	})
+	return found
 }
 
 // Find returns a pointer to the first element for which the given condition is true.
 //
 // If no such element exists, nil is returned.
 func (it Iterator[T]) Find(f func(T) bool) *T {
-	for v := range it {
+	return it.FindOption(f).pointer()
+}
+
+// FindOption works like Find, but returns an Option instead of a pointer.
+func (it Iterator[T]) FindOption(f func(T) bool) Option[T] {
+	var found Option[T]
+	forEach(it, nil, func(v T) bool {
 		if f(v) {
-			return &v
+			found = Some(v)
 		}
-	}
-	return nil
+		return !found.ok
+	})
+	return found
 }
 
 // Position returns the position of the first element for which the given condition is true as a pointer.
 //
 // If no such element exists, nil is returned.
 func (it Iterator[T]) Position(f func(T) bool) *uint {
+	return it.PositionOption(f).pointer()
+}
+
+// PositionOption works like Position, but returns an Option instead of a pointer.
+func (it Iterator[T]) PositionOption(f func(T) bool) Option[uint] {
 	p := uint(0)
-	for v := range it {
+	found := false
+	forEach(it, nil, func(v T) bool {
 		p++
-		if f(v) {
-			return &p
-		}
+		found = f(v)
+		return !found
+	})
+	if !found {
+		return None[uint]()
 	}
-	return nil
+	return Some(p)
 }
 
 // Interleave creates a new Iterator that alternates between the two given Iterators.
 func (it Iterator[T]) Interleave(other Iterator[T]) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[T]) {
 		for {
 			v1, ok1 := <-it
-			if ok1 {
-				newIter <- v1
+			if ok1 && !e.send(v1) {
+				return
 			}
 			v2, ok2 := <-other
-			if ok2 {
-				newIter <- v2
+			if ok2 && !e.send(v2) {
+				return
 			}
 			if !ok1 && !ok2 {
 				return
 			}
 		}
-	}()
-	return newIter
+	}, it, other)
 }
 
 // InterleaveShortest creates a new Iterator that alternates between the two given Iterators until at least one of them runs out.
 func (it Iterator[T]) InterleaveShortest(other Iterator[T]) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[T]) {
 		for {
 			v1, ok1 := <-it
-			if !ok1 {
+			if !ok1 || !e.send(v1) {
 				return
 			}
-			newIter <- v1
 			v2, ok2 := <-other
-			if !ok2 {
+			if !ok2 || !e.send(v2) {
 				return
 			}
-			newIter <- v2
 		}
-	}()
-	return newIter
+	}, it, other)
 }
 
 // GroupBy returns a list of slices, which elements are grouped by the given condition.
@@ -473,8 +532,40 @@ func (it Iterator[T]) GroupBy(f func(T) bool) [][]T {
 	return result
 }
 
+// ChunkBy groups runs of consecutive elements with equal keys computed by the given function.
+//
+// Unlike GroupBy, ChunkBy is lazy: each run is produced as a Pair of its key
+// and its elements as soon as an element with a different key arrives or the
+// Iterator ends. It thus works on unbounded Iterators that are sorted by key.
+func ChunkBy[T any, K comparable](it Iterator[T], key func(T) K) Iterator[Pair[K, []T]] {
+	return produceBatches(func(e *emitter[Pair[K, []T]]) {
+		var run Pair[K, []T]
+		sent := true
+		forEach(it, e.flush, func(v T) bool {
+			k := key(v)
+			if len(run.Y) > 0 && k != run.X {
+				if sent = e.send(run); !sent {
+					return false
+				}
+				run.Y = nil
+			}
+			run.X = k
+			run.Y = append(run.Y, v)
+			return true
+		})
+		if sent && len(run.Y) > 0 {
+			e.send(run)
+		}
+	}, it)
+}
+
 // Chunks returns a list of slices containing at most n elements of the original Iterator.
+//
+// Chunks panics if n is 0.
 func (it Iterator[T]) Chunks(n uint) [][]T {
+	if n == 0 {
+		panic("iter: Chunks called with n == 0")
+	}
 	var result [][]T
 	var currentChunk []T
 Loop:
@@ -496,7 +587,12 @@ Loop:
 }
 
 // Windows returns all overlapping subslices of length n of the original Iterator.
+//
+// Windows panics if n is 0.
 func (it Iterator[T]) Windows(n uint) [][]T {
+	if n == 0 {
+		panic("iter: Windows called with n == 0")
+	}
 	var result [][]T
 	var currentWindow []T
 	for i := uint(0); i < n; i++ {
@@ -529,46 +625,40 @@ func (it Iterator[T]) Windows(n uint) [][]T {
 
 // CartesianProduct returns an Iterator over the cartesian product of both given Iterators.
 func CartesianProduct[T, K any](it Iterator[T], other Iterator[K]) Iterator[Pair[T, K]] {
-	newIter := make(chan Pair[T, K])
-	go func() {
-		defer close(newIter)
+	return produce(func(e *emitter[Pair[T, K]]) {
 		var elementBuffer []K
 		for v := range it {
 			if elementBuffer == nil {
 				for vo := range other {
 					elementBuffer = append(elementBuffer, vo)
-					newIter <- Pair[T, K]{X: v, Y: vo}
+					if !e.send(Pair[T, K]{X: v, Y: vo}) {
+						return
+					}
 				}
 			} else {
 				for _, vo := range elementBuffer {
-					newIter <- Pair[T, K]{X: v, Y: vo}
+					if !e.send(Pair[T, K]{X: v, Y: vo}) {
+						return
+					}
 				}
 			}
 		}
-	}()
-	return newIter
+	}, it, other)
 }
 
 // Dedup removes duplicates from sections of consecutive elements determined by the given condition.
 func (it Iterator[T]) Dedup(f func(T, T) bool) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		var lastElem *T
-		for v := range it {
-			if lastElem == nil {
-				cp := v
-				lastElem = &cp
-				newIter <- v
-			} else {
-				if !f(*lastElem, v) {
-					newIter <- v
-				}
-				*lastElem = v
-			}
+	var lastElem *T
+	return apply(it, func(v T) (T, bool) {
+		if lastElem == nil {
+			cp := v
+			lastElem = &cp
+			return v, true
 		}
-	}()
-	return newIter
+		keep := !f(*lastElem, v)
+		*lastElem = v
+		return v, keep
+	})
 }
 
 // Unique produces an Iterator that returns unique elements from the given Iterator determined by the given condition.
@@ -577,19 +667,15 @@ func (it Iterator[T]) Dedup(f func(T, T) bool) Iterator[T] {
 // comparable type. If your type is already comparable, it is enough to just
 // return it in the closure. See the example.
 func Unique[T any, K comparable](it Iterator[T], f func(T) K) Iterator[T] {
-	newIter := make(chan T)
-	go func() {
-		defer close(newIter)
-		m := make(map[K]bool, 0)
-		for v := range it {
-			cmp := f(v)
-			if !m[cmp] {
-				m[cmp] = true
-				newIter <- v
-			}
-		}
-	}()
-	return newIter
+	m := make(map[K]bool, 0)
+	return apply(it, func(v T) (T, bool) {
+		cmp := f(v)
+		if m[cmp] {
+			return v, false
+		}
+		m[cmp] = true
+		return v, true
+	})
 }
 
 // Join combines all elements into a string separated by sep.
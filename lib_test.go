@@ -2,7 +2,9 @@ package iter
 
 import (
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestFromChan(t *testing.T) {
@@ -29,6 +31,17 @@ func TestFromChan(t *testing.T) {
 	}
 }
 
+func TestFromChan_ReceiveOnly(t *testing.T) {
+	c := make(chan int, 2)
+	c <- 1
+	c <- 2
+	close(c)
+	var r <-chan int = c
+	if s := FromChan(r).Collect(); len(s) != 2 || s[1] != 2 {
+		t.Errorf("FromChan did not work for receive-only channels\nit: %v\n", s)
+	}
+}
+
 func TestFromSlice(t *testing.T) {
 	str := []string{"this", "is", "a", "test"}
 	strIter := FromSlice(str)
@@ -729,3 +742,275 @@ func TestIterator_Join(t *testing.T) {
 		return
 	}
 }
+
+func TestIterator_Close(t *testing.T) {
+	slice := make([]int, 1000)
+	source := FromSlice(slice)
+	it := source.Map(func(x int) int { return x + 1 }).Filter(func(x int) bool { return x > 0 })
+	if v := <-it; v != 1 {
+		t.Errorf("Close did not work\nit: %d\nexpected: %d\n", v, 1)
+	}
+	it.Close()
+	if n := it.Count() + source.Count(); n > 100 {
+		t.Errorf("Close did not stop the pipeline\nremaining: %d\n", n)
+	}
+
+	c := make(chan int)
+	FromChan(c).Close()
+	close(c)
+}
+
+// endless returns an infinite Iterator and a channel that is closed once its goroutine has stopped.
+func endless() (Iterator[int], chan struct{}) {
+	stopped := make(chan struct{})
+	it := produce(func(e *emitter[int]) {
+		defer close(stopped)
+		for i := 0; e.send(i); i++ {
+		}
+	})
+	return it, stopped
+}
+
+func expectStopped(t *testing.T, name string, stopped chan struct{}) {
+	t.Helper()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Errorf("%s did not close its input", name)
+	}
+}
+
+func TestIterator_CloseEarlyEnd(t *testing.T) {
+	it, stopped := endless()
+	taken := it.Take(3)
+	taken.Count()
+	if v, _ := it.Next(); v != 3 {
+		t.Errorf("Take did not leave the rest of its input\nnext: %d\n", v)
+	}
+	taken.Close()
+	expectStopped(t, "Take", stopped)
+
+	it, stopped = endless()
+	taken = it.TakeWhile(func(x int) bool { return x < 3 })
+	taken.Count()
+	taken.Close()
+	expectStopped(t, "TakeWhile", stopped)
+
+	it, stopped = endless()
+	zipped := Zip(FromSlice([]int{1, 2}), it)
+	zipped.Count()
+	zipped.Close()
+	expectStopped(t, "Zip", stopped)
+
+	it, stopped = endless()
+	interleaved := FromSlice([]int{1, 2}).InterleaveShortest(it)
+	interleaved.Count()
+	interleaved.Close()
+	expectStopped(t, "InterleaveShortest", stopped)
+
+	it, stopped = endless()
+	pipeline := it.Map(func(x int) int { return x * 2 }).Take(3).Take(2)
+	pipeline.Count()
+	pipeline.Close()
+	expectStopped(t, "Close after Take", stopped)
+}
+
+func TestIterator_EarlyEndLeavesInput(t *testing.T) {
+	it := FromSlice([]int{1, 2, 3, 4, 5, 6})
+	if v := it.Take(2).Collect(); fmt.Sprint(v) != "[1 2]" {
+		t.Errorf("Take did not work\nit: %v\n", v)
+	}
+	if v := it.Collect(); fmt.Sprint(v) != "[3 4 5 6]" {
+		t.Errorf("Take did not leave the rest of its input\nit: %v\n", v)
+	}
+
+	// Take stays closable until its input is released.
+	it = FromSlice([]int{1, 2, 3})
+	taken := it.Take(1)
+	taken.Count()
+	if taken.stage() == nil {
+		t.Errorf("Take was released before its input ended")
+	}
+	it.Collect()
+	deadline := time.Now().Add(2 * time.Second)
+	for taken.stage() != nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if taken.stage() != nil {
+		t.Errorf("Take was not released after its input ended")
+	}
+
+	it = FromSlice([]int{1, 2, 3, 4, 5, 6})
+	if v := *it.Nth(2); v != 2 {
+		t.Errorf("Nth did not work\nv: %d\n", v)
+	}
+	if v := *it.Nth(1); v != 3 {
+		t.Errorf("Nth did not leave the rest of its input\nv: %d\n", v)
+	}
+
+	it = FromSlice([]int{1, 2, 3, 4, 5, 6}).Map(func(x int) int { return x * 10 })
+	if v := *it.Find(func(x int) bool { return x == 30 }); v != 30 {
+		t.Errorf("Find did not work\nv: %d\n", v)
+	}
+	if v := it.Collect(); fmt.Sprint(v) != "[40 50 60]" {
+		t.Errorf("Find did not leave the rest of its input\nit: %v\n", v)
+	}
+
+	it = FromSlice([]int{1, 2, 3, 4, 5, 6})
+	it.Any(func(x int) bool { return x == 2 })
+	it.All(func(x int) bool { return x < 3 })
+	it.Position(func(x int) bool { return x == 4 })
+	if v := it.Collect(); fmt.Sprint(v) != "[5 6]" {
+		t.Errorf("Any, All and Position did not leave the rest of their input\nit: %v\n", v)
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	i := 0
+	it := FromFunc(func() (int, bool) {
+		i++
+		return i, true
+	})
+	if v := it.Take(3).Collect(); len(v) != 3 || v[2] != 3 {
+		t.Errorf("FromFunc did not work\nit: %v\n", v)
+	}
+}
+
+func TestIterator_Fusion(t *testing.T) {
+	source := FromSlice([]int{1, 2, 3, 4, 5, 6})
+	it := source.Filter(func(x int) bool { return x%2 == 0 }).Map(func(x int) int { return x * 10 })
+	s, _ := stages.Load(source)
+	fused, _ := stages.Load(it)
+	if s == nil || s != fused {
+		t.Errorf("Filter and Map were not fused into the source")
+	}
+	if v := it.Collect(); fmt.Sprint(v) != "[20 40 60]" {
+		t.Errorf("fused pipeline did not work\nit: %v\n", v)
+	}
+
+	source = FromSlice([]int{1, 2, 3, 4, 5, 6})
+	if v := source.Skip(2).Filter(func(x int) bool { return x%2 == 0 }).Collect(); fmt.Sprint(v) != "[4 6]" {
+		t.Errorf("Filter after Skip did not work\nit: %v\n", v)
+	}
+
+	it, stopped := endless()
+	dropped := it.Filter(func(int) bool { return false })
+	dropped.Close()
+	expectStopped(t, "Close of fused Filter dropping everything", stopped)
+}
+
+func TestIterator_Batches(t *testing.T) {
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = i
+	}
+	it := MapInto(FromSlice(s).Filter(func(x int) bool { return x%3 == 0 }), func(x int) int { return x * 2 })
+	v := it.Take(100).Collect()
+	if len(v) != 100 || v[0] != 0 || v[99] != 594 {
+		t.Errorf("batched pipeline did not work\nit: %v\n", v)
+	}
+
+	clock := newFakeClock()
+	ticks := MapInto(TickWithClock(clock, time.Second), func(time.Time) int { return 1 })
+	found := make(chan int, 1)
+	go ticks.ForEach(func(v int) { found <- v })
+	clock.Advance(time.Second)
+	select {
+	case <-found:
+	case <-time.After(2 * time.Second):
+		t.Errorf("element of a batched stage was held back")
+	}
+	ticks.Close()
+
+	// Consumers that stop early must not make fused functions run ahead.
+	var calls atomic.Int32
+	count := func(x int) int {
+		calls.Add(1)
+		return x
+	}
+	FromSlice(s).Map(count).Take(2).Collect()
+	FromSlice(s).Map(count).Find(func(x int) bool { return x == 1 })
+	if n := calls.Load(); n > 6 {
+		t.Errorf("fused functions ran ahead of early stopping consumers\ncalls: %d\n", n)
+	}
+}
+
+func expectPanic(t *testing.T, msg string, f func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != msg {
+			t.Errorf("unexpected panic\ngot: %v\nexpected: %s\n", r, msg)
+		}
+	}()
+	f()
+}
+
+func TestIterator_InvalidArguments(t *testing.T) {
+	s := []int{1, 2, 3}
+	expectPanic(t, "iter: Nth called with n == 0", func() { FromSlice(s).Nth(0) })
+	expectPanic(t, "iter: NthOption called with n == 0", func() { FromSlice(s).NthOption(0) })
+	expectPanic(t, "iter: StepBy called with n == 0", func() { FromSlice(s).StepBy(0) })
+	expectPanic(t, "iter: Chunks called with n == 0", func() { FromSlice(s).Chunks(0) })
+	expectPanic(t, "iter: Windows called with n == 0", func() { FromSlice(s).Windows(0) })
+}
+
+func TestChunkBy(t *testing.T) {
+	s := []string{"a1", "a2", "b1", "a3", "c1", "c2"}
+	first := func(s string) byte { return s[0] }
+	v := fmt.Sprint(ChunkBy(FromSlice(s), first).Collect())
+	if v != "[{97 [a1 a2]} {98 [b1]} {97 [a3]} {99 [c1 c2]}]" {
+		t.Errorf("ChunkBy did not work\nit: %s\n", v)
+	}
+	if v := ChunkBy(FromSlice([]string{}), first).Collect(); len(v) != 0 {
+		t.Errorf("ChunkBy did not work for empty Iterators\nit: %v\n", v)
+	}
+
+	// A run is produced as soon as the next one starts, even if the input never ends.
+	ids, stopped := endless()
+	runs := ChunkBy(ids, func(x int) int { return x / 3 })
+	if run, _ := runs.Next(); run.X != 0 || fmt.Sprint(run.Y) != "[0 1 2]" {
+		t.Errorf("ChunkBy did not work lazily\nrun: %v\n", run)
+	}
+	runs.Close()
+	expectStopped(t, "ChunkBy", stopped)
+}
+
+func TestFoldInto(t *testing.T) {
+	words := []string{"go", "is", "fun"}
+	lengths := FoldInto(FromSlice(words), map[string]int{}, func(m map[string]int, s string) map[string]int {
+		m[s] = len(s)
+		return m
+	})
+	if len(lengths) != 3 || lengths["fun"] != 3 {
+		t.Errorf("FoldInto did not work\nacc: %v\n", lengths)
+	}
+	if v := FoldInto(FromSlice([]int{}), "empty", func(a string, _ int) string { return a + "!" }); v != "empty" {
+		t.Errorf("FoldInto did not return the initial accumulator\nacc: %s\n", v)
+	}
+
+	sum := func(a float64, x int) float64 { return a + float64(x)/2 }
+	if v := ReduceInto(FromSlice([]int{1, 2, 3}), func(x int) float64 { return float64(x) }, sum); v != Some(3.5) {
+		t.Errorf("ReduceInto did not work\nacc: %v\n", v)
+	}
+	if v := ReduceInto(FromSlice([]int{}), func(x int) float64 { return float64(x) }, sum); v.IsSome() {
+		t.Errorf("ReduceInto did not work for empty Iterators\nacc: %v\n", v)
+	}
+}
+
+func TestScan(t *testing.T) {
+	sum := func(a, x int) int { return a + x }
+	if v := fmt.Sprint(Scan(FromSlice([]int{1, 2, 3, 4}), 10, sum).Collect()); v != "[11 13 16 20]" {
+		t.Errorf("Scan did not work\nit: %s\n", v)
+	}
+	if v := Scan(FromSlice([]int{}), 10, sum).Collect(); len(v) != 0 {
+		t.Errorf("Scan did not work for empty Iterators\nit: %v\n", v)
+	}
+
+	it, stopped := endless()
+	totals := Scan(it, 0, sum).Take(4)
+	if v := totals.Collect(); fmt.Sprint(v) != "[0 1 3 6]" {
+		t.Errorf("Scan did not work on an unbounded Iterator\nit: %v\n", v)
+	}
+	totals.Close()
+	expectStopped(t, "Scan", stopped)
+}
@@ -0,0 +1,62 @@
+package iter
+
+// ToMap consumes the Iterator, building a map from the keys and values computed for its elements.
+//
+// If several elements have the same key, merge is called with the key, the
+// value in the map so far and the value of the later element, and its result
+// is stored. If merge is nil, the value of the later element is stored.
+func ToMap[T any, K comparable, V any](it Iterator[T], key func(T) K, value func(T) V, merge func(K, V, V) V) map[K]V {
+	m := make(map[K]V)
+	drain(it, nil, func(v T) {
+		k, x := key(v), value(v)
+		if old, ok := m[k]; ok && merge != nil {
+			x = merge(k, old, x)
+		}
+		m[k] = x
+	})
+	return m
+}
+
+// CollectPairs consumes an Iterator of Pairs, returning a map of their X to their Y.
+//
+// It is the counterpart of FromMap. If several Pairs have the same X, the
+// last one is kept.
+func CollectPairs[K comparable, V any](it Iterator[Pair[K, V]]) map[K]V {
+	m := make(map[K]V)
+	drain(it, nil, func(p Pair[K, V]) {
+		m[p.X] = p.Y
+	})
+	return m
+}
+
+// GroupByKey consumes the Iterator, grouping its elements by the key computed by the given function.
+//
+// Unlike GroupBy, the elements of a group do not need to be consecutive. Within
+// each group, the elements keep their order.
+func GroupByKey[T any, K comparable](it Iterator[T], key func(T) K) map[K][]T {
+	m := make(map[K][]T)
+	drain(it, nil, func(v T) {
+		k := key(v)
+		m[k] = append(m[k], v)
+	})
+	return m
+}
+
+// GroupByKeyOrdered works like GroupByKey, but returns the groups as Pairs of key and elements.
+//
+// The groups are ordered by the first occurrence of their key.
+func GroupByKeyOrdered[T any, K comparable](it Iterator[T], key func(T) K) []Pair[K, []T] {
+	var groups []Pair[K, []T]
+	index := make(map[K]int)
+	drain(it, nil, func(v T) {
+		k := key(v)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Pair[K, []T]{X: k})
+		}
+		groups[i].Y = append(groups[i].Y, v)
+	})
+	return groups
+}
@@ -0,0 +1,72 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToMap(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	first := func(s string) byte { return s[0] }
+	length := func(s string) int { return len(s) }
+
+	m := ToMap(FromSlice(words), first, length, nil)
+	if len(m) != 3 || m['a'] != 7 || m['b'] != 9 || m['c'] != 6 {
+		t.Errorf("ToMap did not keep the last value\nm: %v\n", m)
+	}
+
+	sum := func(_ byte, a, b int) int { return a + b }
+	m = ToMap(FromSlice(words), first, length, sum)
+	if len(m) != 3 || m['a'] != 12 || m['b'] != 15 || m['c'] != 6 {
+		t.Errorf("ToMap did not merge the values\nm: %v\n", m)
+	}
+
+	keepFirst := func(_ byte, a, _ string) string { return a }
+	s := ToMap(FromSlice(words), first, strings.ToUpper, keepFirst)
+	if s['a'] != "APPLE" || s['b'] != "BANANA" {
+		t.Errorf("ToMap did not keep the first value\nm: %v\n", s)
+	}
+}
+
+func TestCollectPairs(t *testing.T) {
+	m := map[int]string{1: "1", 2: "2", 3: "3"}
+	c := CollectPairs(FromMap(m))
+	if len(c) != len(m) {
+		t.Errorf("CollectPairs did not work\nm: %v\n", c)
+	}
+	for k, v := range m {
+		if c[k] != v {
+			t.Errorf("CollectPairs did not work\nm: %v\n", c)
+		}
+	}
+
+	c = CollectPairs(FromSlice([]Pair[int, string]{{1, "a"}, {1, "b"}}))
+	if len(c) != 1 || c[1] != "b" {
+		t.Errorf("CollectPairs did not keep the last Pair\nm: %v\n", c)
+	}
+}
+
+func TestGroupByKey(t *testing.T) {
+	words := []string{"banana", "apple", "blueberry", "cherry", "avocado"}
+	first := func(s string) byte { return s[0] }
+
+	m := GroupByKey(FromSlice(words), first)
+	if len(m) != 3 || strings.Join(m['a'], ",") != "apple,avocado" ||
+		strings.Join(m['b'], ",") != "banana,blueberry" || strings.Join(m['c'], ",") != "cherry" {
+		t.Errorf("GroupByKey did not work\nm: %v\n", m)
+	}
+	if m := GroupByKey(FromSlice([]string{}), first); len(m) != 0 {
+		t.Errorf("GroupByKey did not work for empty Iterators\nm: %v\n", m)
+	}
+
+	groups := GroupByKeyOrdered(FromSlice(words), first)
+	if len(groups) != 3 {
+		t.Fatalf("GroupByKeyOrdered did not work\ngroups: %v\n", groups)
+	}
+	expected := []Pair[byte, string]{{'b', "banana,blueberry"}, {'a', "apple,avocado"}, {'c', "cherry"}}
+	for i, e := range expected {
+		if groups[i].X != e.X || strings.Join(groups[i].Y, ",") != e.Y {
+			t.Errorf("GroupByKeyOrdered did not work\ngroups: %v\n", groups)
+		}
+	}
+}
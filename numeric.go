@@ -0,0 +1,138 @@
+package iter
+
+import (
+	"errors"
+	"math"
+)
+
+// Integer is satisfied by all integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is satisfied by all floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is satisfied by all integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// ErrOverflow is returned by SumChecked and ProductChecked if the result does not fit into the element type.
+var ErrOverflow = errors.New("iter: integer overflow")
+
+// Sum returns the sum of all elements, consuming the Iterator in the process.
+//
+// Integers wrap around on overflow, see SumChecked. Floats are added
+// naively, see SumKahan. The sum of an empty Iterator is 0.
+func Sum[T Number](it Iterator[T]) T {
+	var sum T
+	drain(it, nil, func(v T) {
+		sum += v
+	})
+	return sum
+}
+
+// Product returns the product of all elements, consuming the Iterator in the process.
+//
+// Integers wrap around on overflow, see ProductChecked. The product of an
+// empty Iterator is 1.
+func Product[T Number](it Iterator[T]) T {
+	product := T(1)
+	drain(it, nil, func(v T) {
+		product *= v
+	})
+	return product
+}
+
+// SumChecked works like Sum, but returns ErrOverflow as soon as the sum overflows.
+//
+// The rest of the Iterator is left unconsumed in that case.
+func SumChecked[T Integer](it Iterator[T]) (T, error) {
+	var sum T
+	overflow := false
+	forEach(it, nil, func(v T) bool {
+		s := sum + v
+		overflow = (v > 0 && s < sum) || (v < 0 && s > sum)
+		sum = s
+		return !overflow
+	})
+	if overflow {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// ProductChecked works like Product, but returns ErrOverflow as soon as the product overflows.
+//
+// The rest of the Iterator is left unconsumed in that case.
+func ProductChecked[T Integer](it Iterator[T]) (T, error) {
+	product := T(1)
+	overflow := false
+	forEach(it, nil, func(v T) bool {
+		p := product * v
+		// p == v catches the most negative value multiplied by -1, which p/product does not.
+		overflow = product != 0 && (p/product != v || (p == v && product != 1 && v != 0))
+		product = p
+		return !overflow
+	})
+	if overflow {
+		return 0, ErrOverflow
+	}
+	return product, nil
+}
+
+// kahan is a compensated sum, accumulating the rounding errors of its additions.
+//
+// It uses Neumaier's variant of the Kahan summation, which also handles
+// summands larger than the sum so far.
+type kahan struct {
+	sum, c float64
+}
+
+func (k *kahan) add(v float64) {
+	t := k.sum + v
+	if math.Abs(k.sum) >= math.Abs(v) {
+		k.c += (k.sum - t) + v
+	} else {
+		k.c += (v - t) + k.sum
+	}
+	k.sum = t
+}
+
+func (k *kahan) result() float64 {
+	return k.sum + k.c
+}
+
+// SumKahan returns the sum of all elements using compensated summation, consuming the Iterator in the process.
+//
+// Unlike Sum, the rounding errors of adding many floats do not accumulate,
+// so the result is accurate even for large Iterators.
+func SumKahan[T Float](it Iterator[T]) T {
+	var k kahan
+	drain(it, nil, func(v T) {
+		k.add(float64(v))
+	})
+	return T(k.result())
+}
+
+// Mean returns the arithmetic mean of all elements, consuming the Iterator in the process.
+//
+// The elements are converted to float64 and summed using compensated
+// summation, so integers do not overflow. If the Iterator is empty, None is
+// returned.
+func Mean[T Number](it Iterator[T]) Option[float64] {
+	var k kahan
+	n := 0
+	drain(it, nil, func(v T) {
+		k.add(float64(v))
+		n++
+	})
+	if n == 0 {
+		return None[float64]()
+	}
+	return Some(k.result() / float64(n))
+}
@@ -0,0 +1,92 @@
+package iter
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSumProduct(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	if v := Sum(FromSlice(s)); v != 10 {
+		t.Errorf("Sum did not work\nv: %d\n", v)
+	}
+	if v := Product(FromSlice(s)); v != 24 {
+		t.Errorf("Product did not work\nv: %d\n", v)
+	}
+	if Sum(FromSlice([]float64{})) != 0 || Product(FromSlice([]float64{})) != 1 {
+		t.Errorf("Sum or Product did not work for empty Iterators")
+	}
+	if v := Sum(FromSlice([]float32{0.5, 0.25})); v != 0.75 {
+		t.Errorf("Sum did not work for floats\nv: %v\n", v)
+	}
+}
+
+func TestSumChecked(t *testing.T) {
+	if v, err := SumChecked(FromSlice([]int8{100, 27})); err != nil || v != 127 {
+		t.Errorf("SumChecked did not work\nv: %d\nerr: %v\n", v, err)
+	}
+	if _, err := SumChecked(FromSlice([]int8{100, 28})); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SumChecked did not detect an overflow\nerr: %v\n", err)
+	}
+	if _, err := SumChecked(FromSlice([]int8{-100, -29})); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SumChecked did not detect a negative overflow\nerr: %v\n", err)
+	}
+	if _, err := SumChecked(FromSlice([]uint8{200, 56})); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SumChecked did not detect an unsigned overflow\nerr: %v\n", err)
+	}
+
+	it := FromSlice([]int{math.MaxInt / 2, math.MaxInt / 2, math.MaxInt / 2, 1})
+	if _, err := SumChecked(it); !errors.Is(err, ErrOverflow) {
+		t.Errorf("SumChecked did not detect an overflow\nerr: %v\n", err)
+	}
+	if v := it.Collect(); len(v) != 1 || v[0] != 1 {
+		t.Errorf("SumChecked did not leave the rest of the Iterator\nit: %v\n", v)
+	}
+}
+
+func TestProductChecked(t *testing.T) {
+	if v, err := ProductChecked(FromSlice([]int8{-8, 16})); err != nil || v != -128 {
+		t.Errorf("ProductChecked did not work\nv: %d\nerr: %v\n", v, err)
+	}
+	if v, err := ProductChecked(FromSlice([]int8{0, 100, 100})); err != nil || v != 0 {
+		t.Errorf("ProductChecked did not work with 0\nv: %d\nerr: %v\n", v, err)
+	}
+	for _, s := range [][]int8{{16, 8}, {-128, -1}, {-1, -128}, {-16, -8}} {
+		if _, err := ProductChecked(FromSlice(s)); !errors.Is(err, ErrOverflow) {
+			t.Errorf("ProductChecked did not detect an overflow of %v\nerr: %v\n", s, err)
+		}
+	}
+	if _, err := ProductChecked(FromSlice([]uint8{16, 16})); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ProductChecked did not detect an unsigned overflow\nerr: %v\n", err)
+	}
+}
+
+func TestSumKahan(t *testing.T) {
+	s := make([]float64, 0, 10001)
+	s = append(s, 1e16)
+	for i := 0; i < 10000; i++ {
+		s = append(s, 1)
+	}
+	if v := SumKahan(FromSlice(s)); v != 1e16+10000 {
+		t.Errorf("SumKahan did not compensate\nv: %f\n", v)
+	}
+	if v := Sum(FromSlice(s)); v == 1e16+10000 {
+		t.Errorf("Sum is expected to lose precision here\nv: %f\n", v)
+	}
+	if v := SumKahan(FromSlice([]float64{1, 1e100, 1, -1e100})); v != 2 {
+		t.Errorf("SumKahan did not handle large summands\nv: %f\n", v)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if v := Mean(FromSlice([]int{1, 2, 3, 4})); v != Some(2.5) {
+		t.Errorf("Mean did not work\nv: %v\n", v)
+	}
+	if v := Mean(FromSlice([]int8{127, 127})); v != Some(127.0) {
+		t.Errorf("Mean overflowed\nv: %v\n", v)
+	}
+	if Mean(FromSlice([]float64{})).IsSome() {
+		t.Errorf("Mean did not work for empty Iterators")
+	}
+}
@@ -0,0 +1,56 @@
+package iter
+
+import "fmt"
+
+// Option holds either a value or nothing.
+//
+// It is returned by terminals like FindOption that may not find an element.
+// The zero value of Option holds nothing.
+type Option[T any] struct {
+	v  T
+	ok bool
+}
+
+// Some creates an Option holding v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{v: v, ok: true}
+}
+
+// None creates an Option holding nothing.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// IsSome reports whether the Option holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// Get returns the value of the Option and true, or the zero value and false if it holds nothing.
+func (o Option[T]) Get() (T, bool) {
+	return o.v, o.ok
+}
+
+// OrElse returns the value of the Option, or v if it holds nothing.
+func (o Option[T]) OrElse(v T) T {
+	if o.ok {
+		return o.v
+	}
+	return v
+}
+
+func (o Option[T]) String() string {
+	if o.ok {
+		return fmt.Sprintf("Some(%v)", o.v)
+	}
+	return "None"
+}
+
+// pointer returns a pointer to a copy of the value of the Option, or nil if it holds nothing.
+func (o Option[T]) pointer() *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.v
+	return &v
+}
@@ -0,0 +1,55 @@
+package iter
+
+import "testing"
+
+func TestOption(t *testing.T) {
+	o := Some(0)
+	if v, ok := o.Get(); !ok || v != 0 || !o.IsSome() || o.OrElse(1) != 0 {
+		t.Errorf("Some did not work\no: %v\n", o)
+	}
+	n := None[int]()
+	if v, ok := n.Get(); ok || v != 0 || n.IsSome() || n.OrElse(1) != 1 {
+		t.Errorf("None did not work\no: %v\n", n)
+	}
+	var zero Option[int]
+	if zero.IsSome() || zero.String() != "None" || o.String() != "Some(0)" {
+		t.Errorf("zero value is not None\no: %v\n", zero)
+	}
+}
+
+func TestIterator_OptionTerminals(t *testing.T) {
+	s := []int{0, 1, 2, 3}
+	if v := FromSlice(s).NthOption(1); v != Some(0) {
+		t.Errorf("NthOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).NthOption(5); v.IsSome() {
+		t.Errorf("NthOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).LastOption(); v != Some(3) {
+		t.Errorf("LastOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice([]int{0}).LastOption(); v != Some(0) {
+		t.Errorf("LastOption did not work for the zero value\nv: %v\n", v)
+	}
+	if v := FromSlice([]int{}).LastOption(); v.IsSome() {
+		t.Errorf("LastOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).ReduceOption(func(a, b int) int { return a + b }); v != Some(6) {
+		t.Errorf("ReduceOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice([]int{}).ReduceOption(func(a, b int) int { return a + b }); v.IsSome() {
+		t.Errorf("ReduceOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).FindOption(func(x int) bool { return x > 1 }); v != Some(2) {
+		t.Errorf("FindOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).FindOption(func(x int) bool { return x > 3 }); v.IsSome() {
+		t.Errorf("FindOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).PositionOption(func(x int) bool { return x == 0 }); v != Some[uint](1) {
+		t.Errorf("PositionOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).PositionOption(func(x int) bool { return x > 3 }); v.IsSome() {
+		t.Errorf("PositionOption did not work\nv: %v\n", v)
+	}
+}
@@ -0,0 +1,145 @@
+package iter
+
+import "sort"
+
+// Ordered is satisfied by all types supporting the operators < <= >= >.
+//
+// It matches constraints.Ordered of golang.org/x/exp.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// less is the less function of Ordered types.
+func less[T Ordered](a, b T) bool {
+	return a < b
+}
+
+// sortedKeys returns the keys of m sorted by the given less function.
+func sortedKeys[K comparable, V any](m map[K]V, less func(K, K) bool) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
+
+// FromMapSorted creates an Iterator of Pairs that contain key and value of the given map, sorted by key.
+//
+// The keys are sorted when FromMapSorted is called, later changes of the map
+// are not reflected by the Iterator.
+func FromMapSorted[K Ordered, V any](m map[K]V) Iterator[Pair[K, V]] {
+	return FromMapSortedFunc(m, less[K])
+}
+
+// FromMapSortedFunc works like FromMapSorted, but sorts the keys using the given less function.
+func FromMapSortedFunc[K comparable, V any](m map[K]V, less func(K, K) bool) Iterator[Pair[K, V]] {
+	keys := sortedKeys(m, less)
+	pairs := make([]Pair[K, V], len(keys))
+	for i, key := range keys {
+		pairs[i] = Pair[K, V]{X: key, Y: m[key]}
+	}
+	return FromSlice(pairs)
+}
+
+// FromMapKeysSorted creates an Iterator over the sorted keys of the given map.
+func FromMapKeysSorted[K Ordered, V any](m map[K]V) Iterator[K] {
+	return FromSlice(sortedKeys(m, less[K]))
+}
+
+// FromMapValuesSorted creates an Iterator over the values of the given map, sorted by their keys.
+func FromMapValuesSorted[K Ordered, V any](m map[K]V) Iterator[V] {
+	keys := sortedKeys(m, less[K])
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		values[i] = m[key]
+	}
+	return FromSlice(values)
+}
+
+// Min returns the smallest element of the Iterator, consuming it in the process.
+//
+// If several elements are equally small, the first one is returned. If the
+// Iterator is empty, None is returned.
+func Min[T Ordered](it Iterator[T]) Option[T] {
+	return it.MinBy(less[T])
+}
+
+// Max returns the largest element of the Iterator, consuming it in the process.
+//
+// If several elements are equally large, the first one is returned. If the
+// Iterator is empty, None is returned.
+func Max[T Ordered](it Iterator[T]) Option[T] {
+	return it.MaxBy(less[T])
+}
+
+// MinBy works like Min, but compares the elements using the given less function.
+func (it Iterator[T]) MinBy(less func(T, T) bool) Option[T] {
+	var lo Option[T]
+	drain(it, nil, func(v T) {
+		if !lo.ok || less(v, lo.v) {
+			lo = Some(v)
+		}
+	})
+	return lo
+}
+
+// MaxBy works like Max, but compares the elements using the given less function.
+func (it Iterator[T]) MaxBy(less func(T, T) bool) Option[T] {
+	var hi Option[T]
+	drain(it, nil, func(v T) {
+		if !hi.ok || less(hi.v, v) {
+			hi = Some(v)
+		}
+	})
+	return hi
+}
+
+// MinByKey works like Min, but compares the keys computed for the elements by the given function.
+//
+// The key is computed once per element.
+func MinByKey[T any, K Ordered](it Iterator[T], key func(T) K) Option[T] {
+	var lo Option[T]
+	var loKey K
+	drain(it, nil, func(v T) {
+		if k := key(v); !lo.ok || k < loKey {
+			lo, loKey = Some(v), k
+		}
+	})
+	return lo
+}
+
+// MaxByKey works like Max, but compares the keys computed for the elements by the given function.
+//
+// The key is computed once per element.
+func MaxByKey[T any, K Ordered](it Iterator[T], key func(T) K) Option[T] {
+	var hi Option[T]
+	var hiKey K
+	drain(it, nil, func(v T) {
+		if k := key(v); !hi.ok || k > hiKey {
+			hi, hiKey = Some(v), k
+		}
+	})
+	return hi
+}
+
+// MinMax returns a Pair of the smallest and the largest element of the Iterator in a single pass.
+//
+// Ties are resolved like in Min and Max. If the Iterator is empty, None is returned.
+func MinMax[T Ordered](it Iterator[T]) Option[Pair[T, T]] {
+	var mm Option[Pair[T, T]]
+	drain(it, nil, func(v T) {
+		switch {
+		case !mm.ok:
+			mm = Some(Pair[T, T]{X: v, Y: v})
+		case v < mm.v.X:
+			mm.v.X = v
+		case v > mm.v.Y:
+			mm.v.Y = v
+		}
+	})
+	return mm
+}
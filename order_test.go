@@ -0,0 +1,65 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFromMapSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2, "d": 4}
+	if v := fmt.Sprint(FromMapSorted(m).Collect()); v != "[{a 1} {b 2} {c 3} {d 4}]" {
+		t.Errorf("FromMapSorted did not work\nit: %s\n", v)
+	}
+	desc := FromMapSortedFunc(m, func(a, b string) bool { return a > b })
+	if v := fmt.Sprint(desc.Collect()); v != "[{d 4} {c 3} {b 2} {a 1}]" {
+		t.Errorf("FromMapSortedFunc did not work\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(FromMapKeysSorted(m).Collect()); v != "[a b c d]" {
+		t.Errorf("FromMapKeysSorted did not work\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(FromMapValuesSorted(m).Collect()); v != "[1 2 3 4]" {
+		t.Errorf("FromMapValuesSorted did not work\nit: %s\n", v)
+	}
+	if v := FromMapSorted(map[int]int{}).Collect(); len(v) != 0 {
+		t.Errorf("FromMapSorted did not work for empty maps\nit: %v\n", v)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	if v := Min(FromSlice(s)); v != Some(1) {
+		t.Errorf("Min did not work\nv: %v\n", v)
+	}
+	if v := Max(FromSlice(s)); v != Some(9) {
+		t.Errorf("Max did not work\nv: %v\n", v)
+	}
+	if v := MinMax(FromSlice(s)); v != Some(Pair[int, int]{1, 9}) {
+		t.Errorf("MinMax did not work\nv: %v\n", v)
+	}
+	if v := MinMax(FromSlice([]int{7})); v != Some(Pair[int, int]{7, 7}) {
+		t.Errorf("MinMax did not work for one element\nv: %v\n", v)
+	}
+	empty := []int{}
+	if Min(FromSlice(empty)).IsSome() || Max(FromSlice(empty)).IsSome() || MinMax(FromSlice(empty)).IsSome() {
+		t.Errorf("Min, Max or MinMax did not work for empty Iterators")
+	}
+
+	words := []string{"bb", "a", "cc", "d"}
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	if v := FromSlice(words).MinBy(byLength); v != Some("a") {
+		t.Errorf("MinBy did not return the first minimum\nv: %v\n", v)
+	}
+	if v := FromSlice(words).MaxBy(byLength); v != Some("bb") {
+		t.Errorf("MaxBy did not return the first maximum\nv: %v\n", v)
+	}
+	length := func(s string) int { return len(s) }
+	if v := MinByKey(FromSlice(words), length); v != Some("a") {
+		t.Errorf("MinByKey did not work\nv: %v\n", v)
+	}
+	if v := MaxByKey(FromSlice(words), length); v != Some("bb") {
+		t.Errorf("MaxByKey did not work\nv: %v\n", v)
+	}
+	if FromSlice(empty).MinBy(func(a, b int) bool { return a < b }).IsSome() || MaxByKey(FromSlice(words[:0]), length).IsSome() {
+		t.Errorf("MinBy or MaxByKey did not work for empty Iterators")
+	}
+}
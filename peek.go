@@ -0,0 +1,70 @@
+package iter
+
+// Peekable wraps a Source, allowing to look at its next element without consuming it.
+//
+// A Peekable is not safe for concurrent use. It implements Source itself, so
+// FromSource turns the remaining elements back into an Iterator.
+type Peekable[T any] struct {
+	s      Source[T]
+	peeked bool
+	v      T
+	ok     bool
+}
+
+// NewPeekable creates a Peekable reading from s.
+func NewPeekable[T any](s Source[T]) *Peekable[T] {
+	return &Peekable[T]{s: s}
+}
+
+// Peekable creates a Peekable reading from the Iterator.
+func (it Iterator[T]) Peekable() *Peekable[T] {
+	return NewPeekable[T](it)
+}
+
+// Peek returns the next element without consuming it.
+//
+// It returns false once the Source is exhausted.
+func (p *Peekable[T]) Peek() (T, bool) {
+	if !p.peeked {
+		p.v, p.ok = p.s.Next()
+		p.peeked = true
+	}
+	return p.v, p.ok
+}
+
+// Next consumes and returns the next element.
+//
+// It returns false once the Source is exhausted.
+func (p *Peekable[T]) Next() (T, bool) {
+	v, ok := p.Peek()
+	if ok {
+		var zero T
+		p.peeked = false
+		p.v = zero
+	}
+	return v, ok
+}
+
+// NextIf consumes and returns the next element if the given condition is true for it.
+//
+// Otherwise, the element is kept and false is returned.
+func (p *Peekable[T]) NextIf(f func(T) bool) (T, bool) {
+	v, ok := p.Peek()
+	if !ok || !f(v) {
+		var zero T
+		return zero, false
+	}
+	return p.Next()
+}
+
+// NextIfEq consumes and returns the next element of p if it is equal to v.
+//
+// Otherwise, the element is kept and false is returned.
+func NextIfEq[T comparable](p *Peekable[T], v T) (T, bool) {
+	return p.NextIf(func(x T) bool { return x == v })
+}
+
+// Close closes the Source of the Peekable using CloseSource.
+func (p *Peekable[T]) Close() {
+	CloseSource(p.s)
+}
@@ -0,0 +1,42 @@
+package iter
+
+import "testing"
+
+func TestPeekable(t *testing.T) {
+	p := FromSlice([]int{1, 2, 3, 4}).Peekable()
+	if v, ok := p.Peek(); !ok || v != 1 {
+		t.Errorf("Peek did not work\nv: %d\nok: %v\n", v, ok)
+	}
+	if v, ok := p.Peek(); !ok || v != 1 {
+		t.Errorf("Peek consumed an element\nv: %d\nok: %v\n", v, ok)
+	}
+	if v, ok := p.Next(); !ok || v != 1 {
+		t.Errorf("Next did not return the peeked element\nv: %d\nok: %v\n", v, ok)
+	}
+	if _, ok := p.NextIf(func(x int) bool { return x > 2 }); ok {
+		t.Errorf("NextIf consumed an element not satisfying the condition")
+	}
+	if v, ok := NextIfEq(p, 2); !ok || v != 2 {
+		t.Errorf("NextIfEq did not work\nv: %d\nok: %v\n", v, ok)
+	}
+	if _, ok := NextIfEq(p, 2); ok {
+		t.Errorf("NextIfEq consumed an element that was not equal")
+	}
+	if v := FromSource[int](p).Collect(); len(v) != 2 || v[0] != 3 || v[1] != 4 {
+		t.Errorf("remaining elements were lost\nit: %v\n", v)
+	}
+	if _, ok := p.Peek(); ok {
+		t.Errorf("Peek did not report the end")
+	}
+	if _, ok := p.NextIf(func(int) bool { return true }); ok {
+		t.Errorf("NextIf did not report the end")
+	}
+}
+
+func TestPeekable_Close(t *testing.T) {
+	it, stopped := endless()
+	p := it.Peekable()
+	p.Peek()
+	p.Close()
+	expectStopped(t, "Peekable", stopped)
+}
@@ -0,0 +1,58 @@
+package pull
+
+import (
+	"testing"
+
+	"github.com/rohrschacht/iter"
+)
+
+var benchInput = func() []int {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}()
+
+func BenchmarkFilterMap(b *testing.B) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	square := func(x int) int { return x * x }
+	b.Run("channel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			iter.FromSlice(benchInput).Filter(isEven).Map(square).Count()
+		}
+	})
+	b.Run("pull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FromSlice(benchInput).Filter(isEven).Map(square).Count()
+		}
+	})
+}
+
+func BenchmarkTakeZip(b *testing.B) {
+	b.Run("channel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			it := iter.Zip(iter.FromSlice(benchInput), iter.FromSlice(benchInput))
+			it.Take(5000).Count()
+			it.Close()
+		}
+	})
+	b.Run("pull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Zip(FromSlice(benchInput), FromSlice(benchInput)).Take(5000).Count()
+		}
+	})
+}
+
+func BenchmarkChunks(b *testing.B) {
+	b.Run("channel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			iter.FromSlice(benchInput).Chunks(64)
+		}
+	})
+	b.Run("pull", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FromSlice(benchInput).Chunks(64)
+		}
+	})
+}
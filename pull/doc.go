@@ -0,0 +1,23 @@
+// Package pull implements the iterators of package iter without goroutines.
+//
+// # About
+//
+// The Iterators of package iter run every stage of a pipeline in its own
+// Goroutine and hand each element over a channel. This is a good fit for
+// expensive stages, but the handoff dominates when the stages are cheap. The
+// Iterators of this package are plain functions that are called to pull the
+// next element, so a pipeline runs on the Goroutine consuming it and every
+// stage costs no more than a function call.
+//
+// The package offers the same operations as package iter. FromIter and
+// Iterator.Iter convert between both kinds of iterators. FromSource turns any
+// iter.Source into an Iterator without starting a Goroutine.
+//
+// # Examples
+//
+//	it := pull.FromSlice([]int{1, 2, 3, 4, 5, 6}).
+//		Filter(func(i int) bool { return i%2 == 0 }).
+//		Map(func(i int) int { return i * i }).
+//		Collect()
+//	expected := []int{4, 16, 36}
+package pull
@@ -0,0 +1,51 @@
+package pull
+
+import (
+	"fmt"
+
+	"github.com/rohrschacht/iter"
+)
+
+func Example() {
+	it := FromSlice([]int{1, 2, 3, 4, 5, 6}).
+		Filter(func(i int) bool { return i%2 == 0 }).
+		Map(func(i int) int { return i * i }).
+		Collect()
+	fmt.Println(it)
+	// output:
+	// [4 16 36]
+}
+
+func ExampleFromIter() {
+	c := iter.FromSlice([]int{1, 2, 3, 4})
+	it := FromIter(c).Map(func(x int) int { return x * 10 })
+	fmt.Println(it.Collect())
+	// output:
+	// [10 20 30 40]
+}
+
+func ExampleIterator_Iter() {
+	it := FromSlice([]int{1, 2, 3}).Iter()
+	for v := range it {
+		fmt.Println(v)
+	}
+	// output:
+	// 1
+	// 2
+	// 3
+}
+
+func ExampleZip() {
+	it := Zip(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b", "c"}))
+	fmt.Println(it.Collect())
+	// output:
+	// [{1 a} {2 b} {3 c}]
+}
+
+func ExampleFromIter_close() {
+	src := iter.FromSlice([]int{1, 2, 3, 4, 5, 6})
+	defer src.Close()
+	fmt.Println(FromIter(src).Take(2).Collect())
+	// output:
+	// [1 2]
+}
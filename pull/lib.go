@@ -0,0 +1,1100 @@
+package pull
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/rohrschacht/iter"
+)
+
+// Iterator can be used to process data in a pipeline pattern.
+//
+// Calling the Iterator returns the next element and true, or false once it is exhausted.
+type Iterator[T any] func() (T, bool)
+
+// FromIter creates an Iterator pulling the elements of a channel based iter.Iterator.
+//
+// The pull Iterator cannot tell when its consumer stops early, e.g. after Take,
+// so it never closes it. If it is not consumed until its end, the caller is
+// responsible for closing it.
+func FromIter[T any](it iter.Iterator[T]) Iterator[T] {
+	return func() (T, bool) {
+		v, ok := <-it
+		return v, ok
+	}
+}
+
+// FromSource creates an Iterator pulling the elements of s.
+//
+// No goroutine is involved, Next is called whenever the Iterator is. Once s is
+// exhausted, it is closed using iter.CloseSource. As with FromIter, the caller
+// is responsible for closing s if the Iterator is not consumed until its end.
+// If s is an Iterator, it is returned as is.
+func FromSource[T any](s iter.Source[T]) Iterator[T] {
+	if it, ok := s.(Iterator[T]); ok {
+		return it
+	}
+	done := false
+	return func() (T, bool) {
+		if done {
+			var zero T
+			return zero, false
+		}
+		v, ok := s.Next()
+		if !ok {
+			done = true
+			iter.CloseSource(s)
+		}
+		return v, ok
+	}
+}
+
+// Next returns the next element of the Iterator.
+//
+// It returns false once the Iterator is exhausted. Next makes Iterator an iter.Source.
+func (it Iterator[T]) Next() (T, bool) {
+	return it()
+}
+
+// Peekable creates an iter.Peekable reading from the Iterator.
+func (it Iterator[T]) Peekable() *iter.Peekable[T] {
+	return iter.NewPeekable[T](it)
+}
+
+// FromSlice creates an Iterator over the given slice.
+func FromSlice[T any](slice []T) Iterator[T] {
+	i := 0
+	return func() (T, bool) {
+		if i >= len(slice) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return slice[i-1], true
+	}
+}
+
+// FromMap creates an Iterator of Pairs that contain key and value of the given map.
+func FromMap[T comparable, K any](m map[T]K) Iterator[iter.Pair[T, K]] {
+	pairs := make([]iter.Pair[T, K], 0, len(m))
+	for key, v := range m {
+		pairs = append(pairs, iter.Pair[T, K]{X: key, Y: v})
+	}
+	return FromSlice(pairs)
+}
+
+// FromMapKeys creates an Iterator over the keys of the given map.
+func FromMapKeys[T comparable, K any](m map[T]K) Iterator[T] {
+	keys := make([]T, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return FromSlice(keys)
+}
+
+// FromMapValues creates an Iterator over the values of the given map.
+func FromMapValues[K comparable, T any](m map[K]T) Iterator[T] {
+	values := make([]T, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return FromSlice(values)
+}
+
+// FromMapSorted creates an Iterator of Pairs that contain key and value of the given map, sorted by key.
+func FromMapSorted[K iter.Ordered, V any](m map[K]V) Iterator[iter.Pair[K, V]] {
+	return FromMapSortedFunc(m, func(a, b K) bool { return a < b })
+}
+
+// FromMapSortedFunc works like FromMapSorted, but sorts the keys using the given less function.
+func FromMapSortedFunc[K comparable, V any](m map[K]V, less func(K, K) bool) Iterator[iter.Pair[K, V]] {
+	pairs := FromMap(m).Collect()
+	sort.Slice(pairs, func(i, j int) bool { return less(pairs[i].X, pairs[j].X) })
+	return FromSlice(pairs)
+}
+
+// FromMapKeysSorted creates an Iterator over the sorted keys of the given map.
+func FromMapKeysSorted[K iter.Ordered, V any](m map[K]V) Iterator[K] {
+	keys := FromMapKeys(m).Collect()
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return FromSlice(keys)
+}
+
+// FromMapValuesSorted creates an Iterator over the values of the given map, sorted by their keys.
+func FromMapValuesSorted[K iter.Ordered, V any](m map[K]V) Iterator[V] {
+	return MapInto(FromMapSorted(m), func(p iter.Pair[K, V]) V { return p.Y })
+}
+
+// Iter converts the Iterator into a channel based iter.Iterator.
+//
+// The elements are pulled by a new Goroutine, which stops once the returned
+// Iterator is closed.
+func (it Iterator[T]) Iter() iter.Iterator[T] {
+	return iter.FromFunc(it)
+}
+
+// Collect consumes the Iterator, returning a slice of all its elements.
+func (it Iterator[T]) Collect() []T {
+	var slice []T
+	for v, ok := it(); ok; v, ok = it() {
+		slice = append(slice, v)
+	}
+	return slice
+}
+
+// Filter uses the given function to determine whether elements should continue through the pipeline.
+func (it Iterator[T]) Filter(f func(T) bool) Iterator[T] {
+	return func() (T, bool) {
+		for v, ok := it(); ok; v, ok = it() {
+			if f(v) {
+				return v, true
+			}
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// Map applies the given function to all elements going through the pipeline.
+func (it Iterator[T]) Map(f func(T) T) Iterator[T] {
+	return func() (T, bool) {
+		v, ok := it()
+		if !ok {
+			return v, false
+		}
+		return f(v), true
+	}
+}
+
+// MapInto applies the given function to all elements and allows for the type to change.
+func MapInto[T, K any](it Iterator[T], f func(T) K) Iterator[K] {
+	return func() (K, bool) {
+		v, ok := it()
+		if !ok {
+			var zero K
+			return zero, false
+		}
+		return f(v), true
+	}
+}
+
+// Skip skips the first n elements of the Iterator.
+//
+// n can be larger than the number of elements in the Iterator, which will empty it.
+func (it Iterator[T]) Skip(n uint) Iterator[T] {
+	return func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := it(); !ok {
+				n = 0
+				break
+			}
+		}
+		return it()
+	}
+}
+
+// Take takes the first n elements of the Iterator.
+//
+// All elements after the first n elements will be discarded.
+func (it Iterator[T]) Take(n uint) Iterator[T] {
+	return func() (T, bool) {
+		if n == 0 {
+			var zero T
+			return zero, false
+		}
+		n--
+		return it()
+	}
+}
+
+// Nth returns a pointer to the element at position n.
+//
+// If there are fewer than n elements in the Iterator, nil is returned. Positions
+// start at 1, Nth panics if n is 0.
+func (it Iterator[T]) Nth(n uint) *T {
+	if n == 0 {
+		panic("pull: Nth called with n == 0")
+	}
+	for i := uint(0); i < n-1; i++ {
+		if _, ok := it(); !ok {
+			return nil
+		}
+	}
+	v, ok := it()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// NthOption works like Nth, but returns an iter.Option instead of a pointer.
+func (it Iterator[T]) NthOption(n uint) iter.Option[T] {
+	if n == 0 {
+		panic("pull: NthOption called with n == 0")
+	}
+	for i := uint(0); i < n-1; i++ {
+		if _, ok := it(); !ok {
+			return iter.None[T]()
+		}
+	}
+	if v, ok := it(); ok {
+		return iter.Some(v)
+	}
+	return iter.None[T]()
+}
+
+// Count consumes the Iterator and returns its number of elements.
+func (it Iterator[T]) Count() uint {
+	c := uint(0)
+	for _, ok := it(); ok; _, ok = it() {
+		c++
+	}
+	return c
+}
+
+// Last returns the last element of the Iterator, consuming it in the process.
+func (it Iterator[T]) Last() T {
+	var l T
+	for v, ok := it(); ok; v, ok = it() {
+		l = v
+	}
+	return l
+}
+
+// LastOption returns the last element of the Iterator, consuming it in the process.
+//
+// If the Iterator is empty, None is returned.
+func (it Iterator[T]) LastOption() iter.Option[T] {
+	l := iter.None[T]()
+	for v, ok := it(); ok; v, ok = it() {
+		l = iter.Some(v)
+	}
+	return l
+}
+
+// StepBy advances the Iterator by n elements every time something is taken.
+//
+// StepBy panics if n is 0.
+func (it Iterator[T]) StepBy(n uint) Iterator[T] {
+	if n == 0 {
+		panic("pull: StepBy called with n == 0")
+	}
+
+	first := true
+	return func() (T, bool) {
+		if !first {
+			for i := uint(0); i < n-1; i++ {
+				if v, ok := it(); !ok {
+					return v, false
+				}
+			}
+		}
+		first = false
+		return it()
+	}
+}
+
+// Chain creates a new Iterator which returns the elements of both Iterators.
+func (it Iterator[T]) Chain(other Iterator[T]) Iterator[T] {
+	return func() (T, bool) {
+		if v, ok := it(); ok {
+			return v, true
+		}
+		return other()
+	}
+}
+
+// Intersperse inserts the separator sep between each element of the Iterator.
+func (it Iterator[T]) Intersperse(sep T) Iterator[T] {
+	started := false
+	var next T
+	hasNext := false
+	return func() (T, bool) {
+		if hasNext {
+			hasNext = false
+			return next, true
+		}
+		v, ok := it()
+		if !ok {
+			return v, false
+		}
+		if !started {
+			started = true
+			return v, true
+		}
+		next, hasNext = v, true
+		return sep, true
+	}
+}
+
+// ForEach executes the given function for each element of the Iterator.
+func (it Iterator[T]) ForEach(f func(T)) {
+	for v, ok := it(); ok; v, ok = it() {
+		f(v)
+	}
+}
+
+// Zip creates a new Iterator that contains Pairs containing the elements of both Iterators.
+//
+// If one of the input Iterators is shorter than the other one, the new Iterator
+// will stop at that point.
+func Zip[T, K any](it Iterator[T], other Iterator[K]) Iterator[iter.Pair[T, K]] {
+	return func() (iter.Pair[T, K], bool) {
+		v1, ok1 := it()
+		if !ok1 {
+			return iter.Pair[T, K]{}, false
+		}
+		v2, ok2 := other()
+		if !ok2 {
+			return iter.Pair[T, K]{}, false
+		}
+		return iter.Pair[T, K]{X: v1, Y: v2}, true
+	}
+}
+
+// SkipWhile discards all elements until the condition of the given function is met once.
+func (it Iterator[T]) SkipWhile(f func(T) bool) Iterator[T] {
+	skipping := true
+	return func() (T, bool) {
+		for skipping {
+			v, ok := it()
+			if !ok || !f(v) {
+				skipping = false
+				return v, ok
+			}
+		}
+		return it()
+	}
+}
+
+// TakeWhile takes elements until the condition of the given function is false once.
+func (it Iterator[T]) TakeWhile(f func(T) bool) Iterator[T] {
+	taking := true
+	return func() (T, bool) {
+		if taking {
+			v, ok := it()
+			if ok && f(v) {
+				return v, true
+			}
+			taking = false
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// Inspect applies the given function on each element while the Iterator is consumed.
+//
+// This is helpful for debugging, see the example.
+func (it Iterator[T]) Inspect(f func(T)) Iterator[T] {
+	return func() (T, bool) {
+		v, ok := it()
+		if ok {
+			f(v)
+		}
+		return v, ok
+	}
+}
+
+// Partition splits the contents of the iterator based on the condition defined in the given function.
+//
+// Two slices are returned. The first slice contains all elements of the Iterator
+// for which f evaluated to true. The second slice contains all elements for
+// which f evaluated to false.
+func (it Iterator[T]) Partition(f func(T) bool) ([]T, []T) {
+	var yes []T
+	var no []T
+	for v, ok := it(); ok; v, ok = it() {
+		if f(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// Fold applies the given function to all elements, folding them into the given accumulator.
+func (it Iterator[T]) Fold(acc T, f func(T, T) T) T {
+	for v, ok := it(); ok; v, ok = it() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// FoldInto works like Fold, but allows the accumulator to have a different type than the elements.
+func FoldInto[T, A any](it Iterator[T], acc A, f func(A, T) A) A {
+	for v, ok := it(); ok; v, ok = it() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// ReduceInto works like ReduceOption, but allows the accumulator to have a different type than the elements.
+//
+// The initial accumulator is created from the first element by init. If the
+// Iterator is empty, None is returned.
+func ReduceInto[T, A any](it Iterator[T], init func(T) A, f func(A, T) A) iter.Option[A] {
+	v, ok := it()
+	if !ok {
+		return iter.None[A]()
+	}
+	acc := init(v)
+	for v, ok := it(); ok; v, ok = it() {
+		acc = f(acc, v)
+	}
+	return iter.Some(acc)
+}
+
+// Scan works like FoldInto, but lazily produces every intermediate accumulator.
+//
+// For each element, f is called with the accumulator so far and the element,
+// and its result is produced and becomes the new accumulator. The initial
+// accumulator itself is not produced. Scan works on unbounded Iterators, e.g.
+// for running totals.
+func Scan[T, A any](it Iterator[T], acc A, f func(A, T) A) Iterator[A] {
+	return func() (A, bool) {
+		v, ok := it()
+		if !ok {
+			var zero A
+			return zero, false
+		}
+		acc = f(acc, v)
+		return acc, true
+	}
+}
+
+// Reduce folds the Iterator using the given function, using the first element as the initial accumulator.
+//
+// Reduce returns a pointer for the accumulated value. If the Iterator is empty, this will be nil.
+func (it Iterator[T]) Reduce(f func(T, T) T) *T {
+	acc, ok := it()
+	if !ok {
+		return nil
+	}
+	for v, ok := it(); ok; v, ok = it() {
+		acc = f(acc, v)
+	}
+	return &acc
+}
+
+// ReduceOption works like Reduce, but returns an iter.Option instead of a pointer.
+func (it Iterator[T]) ReduceOption(f func(T, T) T) iter.Option[T] {
+	acc, ok := it()
+	if !ok {
+		return iter.None[T]()
+	}
+	for v, ok := it(); ok; v, ok = it() {
+		acc = f(acc, v)
+	}
+	return iter.Some(acc)
+}
+
+// All checks whether the given condition is true for all elements.
+func (it Iterator[T]) All(f func(T) bool) bool {
+	for v, ok := it(); ok; v, ok = it() {
+		if !f(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any checks whether there exists one element for which the given condition is true.
+func (it Iterator[T]) Any(f func(T) bool) bool {
+	for v, ok := it(); ok; v, ok = it() {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns a pointer to the first element for which the given condition is true.
+//
+// If no such element exists, nil is returned.
+func (it Iterator[T]) Find(f func(T) bool) *T {
+	for v, ok := it(); ok; v, ok = it() {
+		if f(v) {
+			return &v
+		}
+	}
+	return nil
+}
+
+// FindOption works like Find, but returns an iter.Option instead of a pointer.
+func (it Iterator[T]) FindOption(f func(T) bool) iter.Option[T] {
+	for v, ok := it(); ok; v, ok = it() {
+		if f(v) {
+			return iter.Some(v)
+		}
+	}
+	return iter.None[T]()
+}
+
+// Position returns the position of the first element for which the given condition is true as a pointer.
+//
+// If no such element exists, nil is returned.
+func (it Iterator[T]) Position(f func(T) bool) *uint {
+	p := uint(0)
+	for v, ok := it(); ok; v, ok = it() {
+		p++
+		if f(v) {
+			return &p
+		}
+	}
+	return nil
+}
+
+// PositionOption works like Position, but returns an iter.Option instead of a pointer.
+func (it Iterator[T]) PositionOption(f func(T) bool) iter.Option[uint] {
+	p := uint(0)
+	for v, ok := it(); ok; v, ok = it() {
+		p++
+		if f(v) {
+			return iter.Some(p)
+		}
+	}
+	return iter.None[uint]()
+}
+
+// Interleave creates a new Iterator that alternates between the two given Iterators.
+func (it Iterator[T]) Interleave(other Iterator[T]) Iterator[T] {
+	fromOther := false
+	return func() (T, bool) {
+		first, second := it, other
+		if fromOther {
+			first, second = other, it
+		}
+		fromOther = !fromOther
+		if v, ok := first(); ok {
+			return v, true
+		}
+		fromOther = !fromOther
+		return second()
+	}
+}
+
+// InterleaveShortest creates a new Iterator that alternates between the two given Iterators until at least one of them runs out.
+func (it Iterator[T]) InterleaveShortest(other Iterator[T]) Iterator[T] {
+	fromOther := false
+	done := false
+	return func() (T, bool) {
+		if done {
+			var zero T
+			return zero, false
+		}
+		next := it
+		if fromOther {
+			next = other
+		}
+		fromOther = !fromOther
+		v, ok := next()
+		done = !ok
+		return v, ok
+	}
+}
+
+// GroupBy returns a list of slices, which elements are grouped by the given condition.
+func (it Iterator[T]) GroupBy(f func(T) bool) [][]T {
+	var result [][]T
+	var lastState *bool
+	var currentChunk []T
+	for v, ok := it(); ok; v, ok = it() {
+		state := f(v)
+		if lastState == nil {
+			lastState = &state
+			currentChunk = append(currentChunk, v)
+		} else if state == *lastState {
+			currentChunk = append(currentChunk, v)
+		} else {
+			*lastState = state
+			result = append(result, currentChunk)
+			currentChunk = []T{v}
+		}
+	}
+	result = append(result, currentChunk)
+	return result
+}
+
+// Chunks returns a list of slices containing at most n elements of the original Iterator.
+//
+// Chunks panics if n is 0.
+func (it Iterator[T]) Chunks(n uint) [][]T {
+	if n == 0 {
+		panic("pull: Chunks called with n == 0")
+	}
+	var result [][]T
+	var currentChunk []T
+Loop:
+	for {
+		for i := uint(0); i < n; i++ {
+			v, ok := it()
+			if !ok {
+				break Loop
+			}
+			currentChunk = append(currentChunk, v)
+		}
+		result = append(result, currentChunk)
+		currentChunk = nil
+	}
+	if currentChunk != nil {
+		result = append(result, currentChunk)
+	}
+	return result
+}
+
+// Windows returns all overlapping subslices of length n of the original Iterator.
+//
+// Windows panics if n is 0.
+func (it Iterator[T]) Windows(n uint) [][]T {
+	if n == 0 {
+		panic("pull: Windows called with n == 0")
+	}
+	var result [][]T
+	var currentWindow []T
+	for i := uint(0); i < n; i++ {
+		v, ok := it()
+		if !ok {
+			result = append(result, currentWindow)
+			return result
+		}
+		currentWindow = append(currentWindow, v)
+	}
+	result = append(result, currentWindow)
+	for v, ok := it(); ok; v, ok = it() {
+		newWindow := make([]T, n)
+		copy(newWindow, currentWindow[1:])
+		newWindow[n-1] = v
+		result = append(result, newWindow)
+		currentWindow = newWindow
+	}
+	return result
+}
+
+// CartesianProduct returns an Iterator over the cartesian product of both given Iterators.
+func CartesianProduct[T, K any](it Iterator[T], other Iterator[K]) Iterator[iter.Pair[T, K]] {
+	var elementBuffer []K
+	var current T
+	i := 0
+	started := false
+	return func() (iter.Pair[T, K], bool) {
+		if !started || i == len(elementBuffer) {
+			v, ok := it()
+			if !ok {
+				return iter.Pair[T, K]{}, false
+			}
+			if !started {
+				started = true
+				elementBuffer = other.Collect()
+			}
+			current, i = v, 0
+			if len(elementBuffer) == 0 {
+				return iter.Pair[T, K]{}, false
+			}
+		}
+		i++
+		return iter.Pair[T, K]{X: current, Y: elementBuffer[i-1]}, true
+	}
+}
+
+// Dedup removes duplicates from sections of consecutive elements determined by the given condition.
+func (it Iterator[T]) Dedup(f func(T, T) bool) Iterator[T] {
+	var lastElem *T
+	return func() (T, bool) {
+		for v, ok := it(); ok; v, ok = it() {
+			if lastElem == nil {
+				cp := v
+				lastElem = &cp
+				return v, true
+			}
+			duplicate := f(*lastElem, v)
+			*lastElem = v
+			if !duplicate {
+				return v, true
+			}
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// Unique produces an Iterator that returns unique elements from the given Iterator determined by the given condition.
+//
+// Since Iterator can take any type, f has to convert the element type into a
+// comparable type. If your type is already comparable, it is enough to just
+// return it in the closure. See the example.
+func Unique[T any, K comparable](it Iterator[T], f func(T) K) Iterator[T] {
+	m := make(map[K]bool, 0)
+	return func() (T, bool) {
+		for v, ok := it(); ok; v, ok = it() {
+			cmp := f(v)
+			if !m[cmp] {
+				m[cmp] = true
+				return v, true
+			}
+		}
+		var zero T
+		return zero, false
+	}
+}
+
+// Join combines all elements into a string separated by sep.
+func (it Iterator[T]) Join(sep string) string {
+	out := ""
+	v, ok := it()
+	if !ok {
+		return out
+	}
+	out += fmt.Sprintf("%v", v)
+	for v, ok := it(); ok; v, ok = it() {
+		out += fmt.Sprintf("%s%v", sep, v)
+	}
+	return out
+}
+
+// ToMap consumes the Iterator, building a map from the keys and values computed for its elements.
+//
+// If several elements have the same key, merge is called with the key, the
+// value in the map so far and the value of the later element, and its result
+// is stored. If merge is nil, the value of the later element is stored.
+func ToMap[T any, K comparable, V any](it Iterator[T], key func(T) K, value func(T) V, merge func(K, V, V) V) map[K]V {
+	m := make(map[K]V)
+	for v, ok := it(); ok; v, ok = it() {
+		k, x := key(v), value(v)
+		if old, ok := m[k]; ok && merge != nil {
+			x = merge(k, old, x)
+		}
+		m[k] = x
+	}
+	return m
+}
+
+// CollectPairs consumes an Iterator of Pairs, returning a map of their X to their Y.
+//
+// It is the counterpart of FromMap. If several Pairs have the same X, the
+// last one is kept.
+func CollectPairs[K comparable, V any](it Iterator[iter.Pair[K, V]]) map[K]V {
+	m := make(map[K]V)
+	for p, ok := it(); ok; p, ok = it() {
+		m[p.X] = p.Y
+	}
+	return m
+}
+
+// GroupByKey consumes the Iterator, grouping its elements by the key computed by the given function.
+//
+// Unlike GroupBy, the elements of a group do not need to be consecutive. Within
+// each group, the elements keep their order.
+func GroupByKey[T any, K comparable](it Iterator[T], key func(T) K) map[K][]T {
+	m := make(map[K][]T)
+	for v, ok := it(); ok; v, ok = it() {
+		k := key(v)
+		m[k] = append(m[k], v)
+	}
+	return m
+}
+
+// GroupByKeyOrdered works like GroupByKey, but returns the groups as Pairs of key and elements.
+//
+// The groups are ordered by the first occurrence of their key.
+func GroupByKeyOrdered[T any, K comparable](it Iterator[T], key func(T) K) []iter.Pair[K, []T] {
+	var groups []iter.Pair[K, []T]
+	index := make(map[K]int)
+	for v, ok := it(); ok; v, ok = it() {
+		k := key(v)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, iter.Pair[K, []T]{X: k})
+		}
+		groups[i].Y = append(groups[i].Y, v)
+	}
+	return groups
+}
+
+// ChunkBy groups runs of consecutive elements with equal keys computed by the given function.
+//
+// Unlike GroupBy, ChunkBy is lazy: each run is produced as a Pair of its key
+// and its elements as soon as an element with a different key is pulled or the
+// Iterator ends. It thus works on unbounded Iterators that are sorted by key.
+func ChunkBy[T any, K comparable](it Iterator[T], key func(T) K) Iterator[iter.Pair[K, []T]] {
+	var next iter.Pair[K, []T]
+	return func() (iter.Pair[K, []T], bool) {
+		for v, ok := it(); ok; v, ok = it() {
+			k := key(v)
+			if len(next.Y) > 0 && k != next.X {
+				run := next
+				next = iter.Pair[K, []T]{X: k, Y: []T{v}}
+				return run, true
+			}
+			next.X = k
+			next.Y = append(next.Y, v)
+		}
+		run := next
+		next = iter.Pair[K, []T]{}
+		return run, len(run.Y) > 0
+	}
+}
+
+// CountBy consumes the Iterator, counting its elements by the key computed by the given function.
+func CountBy[T any, K comparable](it Iterator[T], key func(T) K) map[K]uint {
+	m := make(map[K]uint)
+	for v, ok := it(); ok; v, ok = it() {
+		m[key(v)]++
+	}
+	return m
+}
+
+// NewCounter creates an iter.Counter of the elements of the Iterator, consuming it.
+func NewCounter[K comparable](it Iterator[K]) *iter.Counter[K] {
+	c := &iter.Counter[K]{}
+	for k, ok := it(); ok; k, ok = it() {
+		c.Add(k, 1)
+	}
+	return c
+}
+
+// Min returns the smallest element of the Iterator, consuming it in the process.
+//
+// If several elements are equally small, the first one is returned. If the
+// Iterator is empty, None is returned.
+func Min[T iter.Ordered](it Iterator[T]) iter.Option[T] {
+	return it.MinBy(func(a, b T) bool { return a < b })
+}
+
+// Max returns the largest element of the Iterator, consuming it in the process.
+//
+// If several elements are equally large, the first one is returned. If the
+// Iterator is empty, None is returned.
+func Max[T iter.Ordered](it Iterator[T]) iter.Option[T] {
+	return it.MaxBy(func(a, b T) bool { return a < b })
+}
+
+// MinBy works like Min, but compares the elements using the given less function.
+func (it Iterator[T]) MinBy(less func(T, T) bool) iter.Option[T] {
+	lo, ok := it()
+	if !ok {
+		return iter.None[T]()
+	}
+	for v, ok := it(); ok; v, ok = it() {
+		if less(v, lo) {
+			lo = v
+		}
+	}
+	return iter.Some(lo)
+}
+
+// MaxBy works like Max, but compares the elements using the given less function.
+func (it Iterator[T]) MaxBy(less func(T, T) bool) iter.Option[T] {
+	hi, ok := it()
+	if !ok {
+		return iter.None[T]()
+	}
+	for v, ok := it(); ok; v, ok = it() {
+		if less(hi, v) {
+			hi = v
+		}
+	}
+	return iter.Some(hi)
+}
+
+// MinByKey works like Min, but compares the keys computed for the elements by the given function.
+//
+// The key is computed once per element.
+func MinByKey[T any, K iter.Ordered](it Iterator[T], key func(T) K) iter.Option[T] {
+	lo, ok := it()
+	if !ok {
+		return iter.None[T]()
+	}
+	loKey := key(lo)
+	for v, ok := it(); ok; v, ok = it() {
+		if k := key(v); k < loKey {
+			lo, loKey = v, k
+		}
+	}
+	return iter.Some(lo)
+}
+
+// MaxByKey works like Max, but compares the keys computed for the elements by the given function.
+//
+// The key is computed once per element.
+func MaxByKey[T any, K iter.Ordered](it Iterator[T], key func(T) K) iter.Option[T] {
+	hi, ok := it()
+	if !ok {
+		return iter.None[T]()
+	}
+	hiKey := key(hi)
+	for v, ok := it(); ok; v, ok = it() {
+		if k := key(v); k > hiKey {
+			hi, hiKey = v, k
+		}
+	}
+	return iter.Some(hi)
+}
+
+// MinMax returns a Pair of the smallest and the largest element of the Iterator in a single pass.
+//
+// Ties are resolved like in Min and Max. If the Iterator is empty, None is returned.
+func MinMax[T iter.Ordered](it Iterator[T]) iter.Option[iter.Pair[T, T]] {
+	v, ok := it()
+	if !ok {
+		return iter.None[iter.Pair[T, T]]()
+	}
+	mm := iter.Pair[T, T]{X: v, Y: v}
+	for v, ok := it(); ok; v, ok = it() {
+		if v < mm.X {
+			mm.X = v
+		} else if v > mm.Y {
+			mm.Y = v
+		}
+	}
+	return iter.Some(mm)
+}
+
+// Sum returns the sum of all elements, consuming the Iterator in the process.
+//
+// Integers wrap around on overflow, see SumChecked. Floats are added
+// naively, see SumKahan. The sum of an empty Iterator is 0.
+func Sum[T iter.Number](it Iterator[T]) T {
+	var sum T
+	for v, ok := it(); ok; v, ok = it() {
+		sum += v
+	}
+	return sum
+}
+
+// Product returns the product of all elements, consuming the Iterator in the process.
+//
+// Integers wrap around on overflow, see ProductChecked. The product of an
+// empty Iterator is 1.
+func Product[T iter.Number](it Iterator[T]) T {
+	product := T(1)
+	for v, ok := it(); ok; v, ok = it() {
+		product *= v
+	}
+	return product
+}
+
+// SumChecked works like Sum, but returns iter.ErrOverflow as soon as the sum overflows.
+func SumChecked[T iter.Integer](it Iterator[T]) (T, error) {
+	var sum T
+	for v, ok := it(); ok; v, ok = it() {
+		s := sum + v
+		if (v > 0 && s < sum) || (v < 0 && s > sum) {
+			return 0, iter.ErrOverflow
+		}
+		sum = s
+	}
+	return sum, nil
+}
+
+// ProductChecked works like Product, but returns iter.ErrOverflow as soon as the product overflows.
+func ProductChecked[T iter.Integer](it Iterator[T]) (T, error) {
+	product := T(1)
+	for v, ok := it(); ok; v, ok = it() {
+		p := product * v
+		// p == v catches the most negative value multiplied by -1, which p/product does not.
+		if product != 0 && (p/product != v || (p == v && product != 1 && v != 0)) {
+			return 0, iter.ErrOverflow
+		}
+		product = p
+	}
+	return product, nil
+}
+
+// kahan is a compensated sum using Neumaier's variant of the Kahan summation.
+type kahan struct {
+	sum, c float64
+}
+
+func (k *kahan) add(v float64) {
+	t := k.sum + v
+	if math.Abs(k.sum) >= math.Abs(v) {
+		k.c += (k.sum - t) + v
+	} else {
+		k.c += (v - t) + k.sum
+	}
+	k.sum = t
+}
+
+// SumKahan returns the sum of all elements using compensated summation, consuming the Iterator in the process.
+//
+// Unlike Sum, the rounding errors of adding many floats do not accumulate,
+// so the result is accurate even for large Iterators.
+func SumKahan[T iter.Float](it Iterator[T]) T {
+	var k kahan
+	for v, ok := it(); ok; v, ok = it() {
+		k.add(float64(v))
+	}
+	return T(k.sum + k.c)
+}
+
+// Mean returns the arithmetic mean of all elements, consuming the Iterator in the process.
+//
+// The elements are converted to float64 and summed using compensated
+// summation, so integers do not overflow. If the Iterator is empty, None is
+// returned.
+func Mean[T iter.Number](it Iterator[T]) iter.Option[float64] {
+	var k kahan
+	n := 0
+	for v, ok := it(); ok; v, ok = it() {
+		k.add(float64(v))
+		n++
+	}
+	if n == 0 {
+		return iter.None[float64]()
+	}
+	return iter.Some((k.sum + k.c) / float64(n))
+}
+
+// Stats consumes the Iterator, returning an iter.Summary of its elements.
+//
+// The elements are converted to float64.
+func Stats[T iter.Number](it Iterator[T]) *iter.Summary {
+	s := &iter.Summary{}
+	for v, ok := it(); ok; v, ok = it() {
+		s.Add(float64(v))
+	}
+	return s
+}
+
+// Sorted creates an Iterator over the elements of the Iterator, sorted by the given less function.
+//
+// The Iterator is consumed when the result is first called. The sort is not
+// stable, see SortedStable.
+func (it Iterator[T]) Sorted(less func(T, T) bool) Iterator[T] {
+	return it.sorted(func(s []T) {
+		sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
+	})
+}
+
+// SortedStable works like Sorted, but keeps equal elements in their original order.
+func (it Iterator[T]) SortedStable(less func(T, T) bool) Iterator[T] {
+	return it.sorted(func(s []T) {
+		sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+	})
+}
+
+// SortedBy works like SortedStable, but sorts the elements by the key computed by the given function.
+//
+// The key is computed once per element.
+func SortedBy[T any, K iter.Ordered](it Iterator[T], key func(T) K) Iterator[T] {
+	keyed := MapInto(it, func(v T) iter.Pair[K, T] { return iter.Pair[K, T]{X: key(v), Y: v} })
+	sorted := keyed.SortedStable(func(a, b iter.Pair[K, T]) bool { return a.X < b.X })
+	return MapInto(sorted, func(p iter.Pair[K, T]) T { return p.Y })
+}
+
+func (it Iterator[T]) sorted(sortSlice func([]T)) Iterator[T] {
+	var next Iterator[T]
+	return func() (T, bool) {
+		if next == nil {
+			s := it.Collect()
+			sortSlice(s)
+			next = FromSlice(s)
+		}
+		return next()
+	}
+}
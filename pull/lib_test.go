@@ -0,0 +1,437 @@
+package pull
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rohrschacht/iter"
+)
+
+func equal[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFromIter(t *testing.T) {
+	it := FromIter(iter.FromSlice([]int{1, 2, 3})).Collect()
+	expected := []int{1, 2, 3}
+	if !equal(it, expected) {
+		t.Errorf("FromIter did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestIterator_Iter(t *testing.T) {
+	it := FromSlice([]int{1, 2, 3}).Iter().Map(func(x int) int { return x * 2 }).Collect()
+	expected := []int{2, 4, 6}
+	if !equal(it, expected) {
+		t.Errorf("Iter did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	m := map[int]string{1: "1", 2: "2", 3: "3"}
+	if !FromMap(m).All(func(pair iter.Pair[int, string]) bool { return fmt.Sprintf("%d", pair.X) == pair.Y }) {
+		t.Error("FromMap did not work")
+	}
+	if FromMapKeys(m).Fold(0, func(acc, x int) int { return acc + x }) != 6 {
+		t.Error("FromMapKeys did not work")
+	}
+	if FromMapValues(m).Count() != 3 {
+		t.Error("FromMapValues did not work")
+	}
+}
+
+func TestIterator_Adapters(t *testing.T) {
+	tests := []struct {
+		name     string
+		it       Iterator[int]
+		expected []int
+	}{
+		{"Filter", FromSlice([]int{1, 2, 3, 4, 5, 6}).Filter(func(x int) bool { return x%2 == 0 }), []int{2, 4, 6}},
+		{"Map", FromSlice([]int{1, 2, 3}).Map(func(x int) int { return x * x }), []int{1, 4, 9}},
+		{"Skip", FromSlice([]int{1, 2, 3, 4, 5, 6}).Skip(3), []int{4, 5, 6}},
+		{"SkipMuch", FromSlice([]int{1, 2, 3}).Skip(10), nil},
+		{"Take", FromSlice([]int{1, 2, 3, 4, 5, 6}).Take(3), []int{1, 2, 3}},
+		{"TakeMuch", FromSlice([]int{1, 2, 3}).Take(10), []int{1, 2, 3}},
+		{"StepBy", FromSlice([]int{1, 2, 3, 4, 5, 6}).StepBy(2), []int{1, 3, 5}},
+		{"Chain", FromSlice([]int{1, 2}).Chain(FromSlice([]int{3, 4})), []int{1, 2, 3, 4}},
+		{"Intersperse", FromSlice([]int{1, 2, 3}).Intersperse(5), []int{1, 5, 2, 5, 3}},
+		{"SkipWhile", FromSlice([]int{1, 2, 3, 4, 1}).SkipWhile(func(x int) bool { return x < 3 }), []int{3, 4, 1}},
+		{"TakeWhile", FromSlice([]int{1, 2, 3, 4, 1}).TakeWhile(func(x int) bool { return x < 3 }), []int{1, 2}},
+		{"Interleave", FromSlice([]int{1, 2, 3}).Interleave(FromSlice([]int{4, 5, 6, 7, 8})), []int{1, 4, 2, 5, 3, 6, 7, 8}},
+		{"InterleaveShortest", FromSlice([]int{1, 2, 3}).InterleaveShortest(FromSlice([]int{4, 5, 6, 7, 8})), []int{1, 4, 2, 5, 3, 6}},
+		{"Dedup", FromSlice([]int{1, 1, 1, 2, 2, 3, 1}).Dedup(func(x, y int) bool { return x == y }), []int{1, 2, 3, 1}},
+		{"Unique", Unique(FromSlice([]int{1, 1, 2, 2, 1, 3}), func(x int) int { return x }), []int{1, 2, 3}},
+	}
+	for _, test := range tests {
+		if it := test.it.Collect(); !equal(it, test.expected) {
+			t.Errorf("%s did not work\nit: %v\nexpected: %v\n", test.name, it, test.expected)
+		}
+	}
+}
+
+func TestIterator_Inspect(t *testing.T) {
+	n := 0
+	it := FromSlice([]int{1, 2, 3}).Inspect(func(x int) { n += x }).Collect()
+	if n != 6 || !equal(it, []int{1, 2, 3}) {
+		t.Errorf("Inspect did not work\nit: %v\nsum: %d\n", it, n)
+	}
+}
+
+func TestMapInto(t *testing.T) {
+	it := MapInto(FromSlice([]int{1, 2, 3}), func(x int) string { return fmt.Sprintf("%d", x) }).Collect()
+	expected := []string{"1", "2", "3"}
+	if !equal(it, expected) {
+		t.Errorf("MapInto did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestZip(t *testing.T) {
+	it := Zip(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"})).Collect()
+	expected := []iter.Pair[int, string]{{X: 1, Y: "a"}, {X: 2, Y: "b"}}
+	if !equal(it, expected) {
+		t.Errorf("Zip did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	it := CartesianProduct(FromSlice([]int{1, 2}), FromSlice([]int{3, 4, 5})).Collect()
+	expected := []iter.Pair[int, int]{{X: 1, Y: 3}, {X: 1, Y: 4}, {X: 1, Y: 5}, {X: 2, Y: 3}, {X: 2, Y: 4}, {X: 2, Y: 5}}
+	if !equal(it, expected) {
+		t.Errorf("CartesianProduct did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+
+	empty := CartesianProduct(FromSlice([]int{1, 2}), FromSlice([]int{})).Collect()
+	if len(empty) != 0 {
+		t.Errorf("CartesianProduct did not work with an empty Iterator\nit: %v\n", empty)
+	}
+}
+
+func TestIterator_Terminals(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6}
+	if n := FromSlice(s).Nth(3); n == nil || *n != 3 {
+		t.Error("Nth did not work")
+	}
+	if n := FromSlice(s).Nth(7); n != nil {
+		t.Error("Nth did not work")
+	}
+	if c := FromSlice(s).Count(); c != 6 {
+		t.Error("Count did not work")
+	}
+	if l := FromSlice(s).Last(); l != 6 {
+		t.Error("Last did not work")
+	}
+	if n := FromSlice(s).Fold(0, func(acc, x int) int { return acc + x }); n != 21 {
+		t.Error("Fold did not work")
+	}
+	if n := FromSlice(s).Reduce(func(acc, x int) int { return acc * x }); n == nil || *n != 720 {
+		t.Error("Reduce did not work")
+	}
+	if n := FromSlice([]int{}).Reduce(func(acc, x int) int { return acc * x }); n != nil {
+		t.Error("Reduce did not work")
+	}
+	if !FromSlice(s).All(func(x int) bool { return x > 0 }) || FromSlice(s).All(func(x int) bool { return x > 1 }) {
+		t.Error("All did not work")
+	}
+	if !FromSlice(s).Any(func(x int) bool { return x > 5 }) || FromSlice(s).Any(func(x int) bool { return x > 6 }) {
+		t.Error("Any did not work")
+	}
+	if x := FromSlice(s).Find(func(x int) bool { return x > 4 }); x == nil || *x != 5 {
+		t.Error("Find did not work")
+	}
+	if p := FromSlice(s).Position(func(x int) bool { return x > 4 }); p == nil || *p != 5 {
+		t.Error("Position did not work")
+	}
+	if j := FromSlice(s).Join(","); j != "1,2,3,4,5,6" {
+		t.Error("Join did not work")
+	}
+	n := 0
+	FromSlice(s).ForEach(func(x int) { n += x })
+	if n != 21 {
+		t.Error("ForEach did not work")
+	}
+	even, odd := FromSlice(s).Partition(func(x int) bool { return x%2 == 0 })
+	if !equal(even, []int{2, 4, 6}) || !equal(odd, []int{1, 3, 5}) {
+		t.Error("Partition did not work")
+	}
+}
+
+func TestIterator_Slices(t *testing.T) {
+	s := []int{-2, -1, 1, 2, 3, -4, -5, 7, 8}
+	tests := []struct {
+		name     string
+		it       [][]int
+		expected [][]int
+	}{
+		{"GroupBy", FromSlice(s).GroupBy(func(x int) bool { return x > 0 }), [][]int{{-2, -1}, {1, 2, 3}, {-4, -5}, {7, 8}}},
+		{"Chunks", FromSlice(s).Chunks(2), [][]int{{-2, -1}, {1, 2}, {3, -4}, {-5, 7}, {8}}},
+		{"Windows", FromSlice([]int{1, 2, 3, 4, 5}).Windows(3), [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+	}
+	for _, test := range tests {
+		if len(test.it) != len(test.expected) {
+			t.Errorf("%s did not work\nit: %v\nexpected: %v\n", test.name, test.it, test.expected)
+			continue
+		}
+		for i := range test.it {
+			if !equal(test.it[i], test.expected[i]) {
+				t.Errorf("%s did not work\nit: %v\nexpected: %v\n", test.name, test.it, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func expectPanic(t *testing.T, msg string, f func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != msg {
+			t.Errorf("unexpected panic\ngot: %v\nexpected: %s\n", r, msg)
+		}
+	}()
+	f()
+}
+
+func TestIterator_InvalidArguments(t *testing.T) {
+	s := []int{1, 2, 3}
+	expectPanic(t, "pull: Nth called with n == 0", func() { FromSlice(s).Nth(0) })
+	expectPanic(t, "pull: NthOption called with n == 0", func() { FromSlice(s).NthOption(0) })
+	expectPanic(t, "pull: StepBy called with n == 0", func() { FromSlice(s).StepBy(0) })
+	expectPanic(t, "pull: Chunks called with n == 0", func() { FromSlice(s).Chunks(0) })
+	expectPanic(t, "pull: Windows called with n == 0", func() { FromSlice(s).Windows(0) })
+}
+
+func TestIterator_IterClose(t *testing.T) {
+	pulled := 0
+	it := FromSlice(make([]int, 1000)).Inspect(func(int) { pulled++ }).Iter()
+	<-it
+	it.Close()
+	for range it {
+	}
+	if pulled > 100 {
+		t.Errorf("Close did not stop pulling\npulled: %d\n", pulled)
+	}
+}
+
+type cursor struct {
+	rows   []int
+	closed int
+}
+
+func (c *cursor) Next() (int, bool) {
+	if len(c.rows) == 0 {
+		return 0, false
+	}
+	v := c.rows[0]
+	c.rows = c.rows[1:]
+	return v, true
+}
+
+func (c *cursor) Close() {
+	c.closed++
+}
+
+func TestFromSource(t *testing.T) {
+	c := &cursor{rows: []int{1, 2, 3, 4}}
+	it := FromSource[int](c).Filter(func(x int) bool { return x%2 == 0 })
+	v := it.Collect()
+	if len(v) != 2 || v[0] != 2 || v[1] != 4 {
+		t.Errorf("FromSource did not work\nit: %v\n", v)
+	}
+	if _, ok := it(); ok || c.closed != 1 {
+		t.Errorf("FromSource did not close the Source once\nclosed: %d\n", c.closed)
+	}
+
+	var s iter.Source[int] = FromSlice([]int{1, 2})
+	if v := iter.FromSource(s).Collect(); len(v) != 2 {
+		t.Errorf("Iterator is no Source\nit: %v\n", v)
+	}
+}
+
+func TestIterator_Peekable(t *testing.T) {
+	p := FromSlice([]int{1, 2, 3}).Peekable()
+	if v, ok := p.Peek(); !ok || v != 1 {
+		t.Errorf("Peek did not work\nv: %d\nok: %v\n", v, ok)
+	}
+	if v := FromSource[int](p).Collect(); len(v) != 3 {
+		t.Errorf("Peekable lost elements\nit: %v\n", v)
+	}
+}
+
+func TestIterator_OptionTerminals(t *testing.T) {
+	s := []int{0, 1, 2, 3}
+	if v := FromSlice(s).NthOption(2); v != iter.Some(1) {
+		t.Errorf("NthOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice([]int{}).LastOption(); v.IsSome() {
+		t.Errorf("LastOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).ReduceOption(func(a, b int) int { return a + b }); v != iter.Some(6) {
+		t.Errorf("ReduceOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).FindOption(func(x int) bool { return x > 3 }); v.IsSome() {
+		t.Errorf("FindOption did not work\nv: %v\n", v)
+	}
+	if v := FromSlice(s).PositionOption(func(x int) bool { return x == 3 }); v != iter.Some[uint](4) {
+		t.Errorf("PositionOption did not work\nv: %v\n", v)
+	}
+}
+
+func TestFromMapSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2}
+	if v := fmt.Sprint(FromMapSorted(m).Collect()); v != "[{a 1} {b 2} {c 3}]" {
+		t.Errorf("FromMapSorted did not work\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(FromMapKeysSorted(m).Collect()); v != "[a b c]" {
+		t.Errorf("FromMapKeysSorted did not work\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(FromMapValuesSorted(m).Collect()); v != "[1 2 3]" {
+		t.Errorf("FromMapValuesSorted did not work\nit: %s\n", v)
+	}
+}
+
+func TestToMap(t *testing.T) {
+	words := []string{"apple", "avocado", "banana"}
+	sum := func(_ byte, a, b int) int { return a + b }
+	m := ToMap(FromSlice(words), func(s string) byte { return s[0] }, func(s string) int { return len(s) }, sum)
+	if len(m) != 2 || m['a'] != 12 || m['b'] != 6 {
+		t.Errorf("ToMap did not work\nm: %v\n", m)
+	}
+	c := CollectPairs(FromMap(map[int]int{1: 2, 3: 4}))
+	if len(c) != 2 || c[1] != 2 || c[3] != 4 {
+		t.Errorf("CollectPairs did not work\nm: %v\n", c)
+	}
+}
+
+func TestGroupByKey(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	isOdd := func(x int) bool { return x%2 == 1 }
+	m := GroupByKey(FromSlice(s), isOdd)
+	if fmt.Sprint(m[true]) != "[1 3 5]" || fmt.Sprint(m[false]) != "[2 4]" {
+		t.Errorf("GroupByKey did not work\nm: %v\n", m)
+	}
+	if g := GroupByKeyOrdered(FromSlice(s), isOdd); fmt.Sprint(g) != "[{true [1 3 5]} {false [2 4]}]" {
+		t.Errorf("GroupByKeyOrdered did not work\ngroups: %v\n", g)
+	}
+}
+
+func TestChunkBy(t *testing.T) {
+	s := []int{1, 3, 2, 4, 6, 5}
+	isOdd := func(x int) bool { return x%2 == 1 }
+	if v := fmt.Sprint(ChunkBy(FromSlice(s), isOdd).Collect()); v != "[{true [1 3]} {false [2 4 6]} {true [5]}]" {
+		t.Errorf("ChunkBy did not work\nit: %s\n", v)
+	}
+	if v := ChunkBy(FromSlice([]int{}), isOdd).Collect(); len(v) != 0 {
+		t.Errorf("ChunkBy did not work for empty Iterators\nit: %v\n", v)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	m := CountBy(FromSlice(s), func(x int) bool { return x%2 == 1 })
+	if m[true] != 3 || m[false] != 2 {
+		t.Errorf("CountBy did not work\nm: %v\n", m)
+	}
+	c := NewCounter(FromSlice([]int{3, 1, 3}))
+	if v := fmt.Sprint(c.MostCommon(1)); v != "[{3 2}]" {
+		t.Errorf("NewCounter did not work\nmost common: %s\n", v)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	if v := Min(FromSlice(s)); v != iter.Some(1) {
+		t.Errorf("Min did not work\nv: %v\n", v)
+	}
+	if v := Max(FromSlice(s)); v != iter.Some(9) {
+		t.Errorf("Max did not work\nv: %v\n", v)
+	}
+	if v := MinMax(FromSlice(s)); v != iter.Some(iter.Pair[int, int]{X: 1, Y: 9}) {
+		t.Errorf("MinMax did not work\nv: %v\n", v)
+	}
+	words := []string{"bb", "a", "cc"}
+	length := func(s string) int { return len(s) }
+	if v := MinByKey(FromSlice(words), length); v != iter.Some("a") {
+		t.Errorf("MinByKey did not work\nv: %v\n", v)
+	}
+	if v := MaxByKey(FromSlice(words), length); v != iter.Some("bb") {
+		t.Errorf("MaxByKey did not work\nv: %v\n", v)
+	}
+	if Min(FromSlice([]int{})).IsSome() || MinMax(FromSlice([]int{})).IsSome() {
+		t.Errorf("Min or MinMax did not work for empty Iterators")
+	}
+}
+
+func TestSumProductMean(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	if Sum(FromSlice(s)) != 10 || Product(FromSlice(s)) != 24 {
+		t.Errorf("Sum or Product did not work")
+	}
+	if _, err := SumChecked(FromSlice([]int8{100, 28})); err != iter.ErrOverflow {
+		t.Errorf("SumChecked did not detect an overflow\nerr: %v\n", err)
+	}
+	if _, err := ProductChecked(FromSlice([]int8{-128, -1})); err != iter.ErrOverflow {
+		t.Errorf("ProductChecked did not detect an overflow\nerr: %v\n", err)
+	}
+	if v := SumKahan(FromSlice([]float64{1, 1e100, 1, -1e100})); v != 2 {
+		t.Errorf("SumKahan did not compensate\nv: %f\n", v)
+	}
+	if v := Mean(FromSlice(s)); v != iter.Some(2.5) {
+		t.Errorf("Mean did not work\nv: %v\n", v)
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := Stats(FromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9}))
+	if s.Count != 8 || s.Mean != 5 || s.Min != 2 || s.Max != 9 || s.Median() != 4.5 {
+		t.Errorf("Stats did not work\nsummary: %+v\n", s)
+	}
+}
+
+func TestFoldInto(t *testing.T) {
+	var b strings.Builder
+	FoldInto(FromSlice([]int{1, 2, 3}), &b, func(b *strings.Builder, x int) *strings.Builder {
+		fmt.Fprint(b, x)
+		return b
+	})
+	if b.String() != "123" {
+		t.Errorf("FoldInto did not work\nacc: %s\n", b.String())
+	}
+	if v := ReduceInto(FromSlice([]int{2, 3}), func(x int) []int { return []int{x} }, func(a []int, x int) []int { return append(a, x) }); fmt.Sprint(v) != "Some([2 3])" {
+		t.Errorf("ReduceInto did not work\nacc: %v\n", v)
+	}
+}
+
+func TestScan(t *testing.T) {
+	runningMax := func(a, x int) int {
+		if x > a {
+			return x
+		}
+		return a
+	}
+	if v := fmt.Sprint(Scan(FromSlice([]int{3, 1, 4, 1, 5}), 0, runningMax).Collect()); v != "[3 3 4 4 5]" {
+		t.Errorf("Scan did not work\nit: %s\n", v)
+	}
+}
+
+func TestIterator_Sorted(t *testing.T) {
+	asc := func(a, b int) bool { return a < b }
+	if v := fmt.Sprint(FromSlice([]int{5, 2, 8, 1}).Sorted(asc).Collect()); v != "[1 2 5 8]" {
+		t.Errorf("Sorted did not work\nit: %s\n", v)
+	}
+	words := []string{"pear", "fig", "apple", "kiwi"}
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	if v := fmt.Sprint(FromSlice(words).SortedStable(byLength).Collect()); v != "[fig pear kiwi apple]" {
+		t.Errorf("SortedStable did not work\nit: %s\n", v)
+	}
+	if v := fmt.Sprint(SortedBy(FromSlice(words), func(s string) int { return len(s) }).Collect()); v != "[fig pear kiwi apple]" {
+		t.Errorf("SortedBy did not work\nit: %s\n", v)
+	}
+}
@@ -0,0 +1,71 @@
+//go:build go1.23
+
+package iter
+
+import goiter "iter"
+
+// FromSeq creates an Iterator over the values of a standard library iterator.
+//
+// Closing the Iterator stops seq at its next yield.
+func FromSeq[T any](seq goiter.Seq[T]) Iterator[T] {
+	return produce(func(e *emitter[T]) {
+		seq(e.send)
+	})
+}
+
+// FromSeq2 creates an Iterator of Pairs over the key and value pairs of a standard library iterator.
+//
+// Closing the Iterator stops seq at its next yield.
+func FromSeq2[K, V any](seq goiter.Seq2[K, V]) Iterator[Pair[K, V]] {
+	return produce(func(e *emitter[Pair[K, V]]) {
+		seq(func(k K, v V) bool {
+			return e.send(Pair[K, V]{X: k, Y: v})
+		})
+	})
+}
+
+// Seq returns a standard library iterator over the elements of the Iterator.
+//
+// This allows consuming the Iterator with a range-over-func loop. If the loop
+// is left early, the Iterator is closed, which stops the goroutines of its
+// pipeline.
+func (it Iterator[T]) Seq() goiter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range it {
+			if !yield(v) {
+				it.Close()
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns a standard library iterator over the positions and elements of the Iterator.
+//
+// Positions start at 0. Like Seq, leaving the loop early closes the Iterator.
+func (it Iterator[T]) Seq2() goiter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range it {
+			if !yield(i, v) {
+				it.Close()
+				return
+			}
+			i++
+		}
+	}
+}
+
+// ToSeq2 returns a standard library iterator over the Pairs of the Iterator, yielding X and Y as key and value.
+//
+// Like Seq, leaving the loop early closes the Iterator.
+func ToSeq2[K, V any](it Iterator[Pair[K, V]]) goiter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range it {
+			if !yield(p.X, p.Y) {
+				it.Close()
+				return
+			}
+		}
+	}
+}
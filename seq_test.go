@@ -0,0 +1,111 @@
+//go:build go1.23
+
+package iter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFromSeq(t *testing.T) {
+	it := FromSeq(slices.Values([]int{1, 2, 3, 4})).Collect()
+	expected := []int{1, 2, 3, 4}
+	if !slices.Equal(it, expected) {
+		t.Errorf("FromSeq did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestFromSeqClose(t *testing.T) {
+	yielded := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			yielded++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	it := FromSeq(seq)
+	<-it
+	<-it
+	it.Close()
+	for range it {
+	}
+	if yielded > 100 {
+		t.Errorf("FromSeq did not stop the sequence\nyielded: %d\n", yielded)
+	}
+}
+
+func TestFromSeq2(t *testing.T) {
+	it := FromSeq2(slices.All([]string{"a", "b", "c"})).Collect()
+	expected := []Pair[int, string]{{X: 0, Y: "a"}, {X: 1, Y: "b"}, {X: 2, Y: "c"}}
+	if !slices.Equal(it, expected) {
+		t.Errorf("FromSeq2 did not work\nit: %v\nexpected: %v\n", it, expected)
+	}
+}
+
+func TestIterator_Seq(t *testing.T) {
+	var got []int
+	for v := range FromSlice([]int{1, 2, 3}).Seq() {
+		got = append(got, v)
+	}
+	expected := []int{1, 2, 3}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Seq did not work\nit: %v\nexpected: %v\n", got, expected)
+	}
+}
+
+func TestIterator_SeqBreak(t *testing.T) {
+	source := FromSlice(make([]int, 1000))
+	it := source.Map(func(x int) int { return x + 1 })
+	for range it.Seq() {
+		break
+	}
+	if n := it.Count() + source.Count(); n > 100 {
+		t.Errorf("Seq did not close the pipeline on break\nremaining: %d\n", n)
+	}
+}
+
+func TestIterator_Seq2(t *testing.T) {
+	var got []Pair[int, string]
+	for i, v := range FromSlice([]string{"a", "b", "c"}).Seq2() {
+		got = append(got, Pair[int, string]{X: i, Y: v})
+	}
+	expected := []Pair[int, string]{{X: 0, Y: "a"}, {X: 1, Y: "b"}, {X: 2, Y: "c"}}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Seq2 did not work\nit: %v\nexpected: %v\n", got, expected)
+	}
+}
+
+func TestToSeq2(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	got := maps.Collect(ToSeq2(FromMap(m)))
+	if !maps.Equal(got, m) {
+		t.Errorf("ToSeq2 did not work\nit: %v\nexpected: %v\n", got, m)
+	}
+}
+
+func ExampleFromSeq() {
+	it := FromSeq(slices.Values([]int{1, 2, 3, 4, 5, 6})).
+		Filter(func(x int) bool { return x%2 == 0 })
+	fmt.Println(it.Collect())
+	// output:
+	// [2 4 6]
+}
+
+func ExampleIterator_Seq() {
+	it := FromSlice([]int{1, 2, 3, 4, 5, 6}).
+		Map(func(x int) int { return x * x })
+	for v := range it.Seq() {
+		if v > 10 {
+			break
+		}
+		fmt.Println(v)
+	}
+	// output:
+	// 1
+	// 4
+	// 9
+}
@@ -0,0 +1,146 @@
+package iter
+
+import (
+	"sort"
+	"sync"
+)
+
+// SortOption configures the behaviour of Sorted, SortedStable and SortedBy.
+type SortOption func(*sortConfig)
+
+type sortConfig struct {
+	workers int
+}
+
+// minParallelRun is the minimum number of elements each worker of a parallel sort is given.
+const minParallelRun = 4096
+
+// Parallel makes the sorting adapters sort large inputs using up to the given number of goroutines.
+//
+// The input is split into runs that are sorted concurrently and then merged.
+// Inputs too small to benefit are sorted by a single goroutine. Parallel
+// panics if workers is smaller than 1.
+func Parallel(workers int) SortOption {
+	if workers < 1 {
+		panic("iter: Parallel called with workers < 1")
+	}
+	return func(c *sortConfig) {
+		c.workers = workers
+	}
+}
+
+// Sorted creates an Iterator over the elements of the Iterator, sorted by the given less function.
+//
+// Sorting needs all elements, so nothing is produced before the Iterator has
+// ended. The sort is not stable, see SortedStable.
+func (it Iterator[T]) Sorted(less func(T, T) bool, opts ...SortOption) Iterator[T] {
+	return sorted(it, less, false, opts)
+}
+
+// SortedStable works like Sorted, but keeps equal elements in their original order.
+func (it Iterator[T]) SortedStable(less func(T, T) bool, opts ...SortOption) Iterator[T] {
+	return sorted(it, less, true, opts)
+}
+
+// SortedBy works like SortedStable, but sorts the elements by the key computed by the given function.
+//
+// The key is computed once per element.
+func SortedBy[T any, K Ordered](it Iterator[T], key func(T) K, opts ...SortOption) Iterator[T] {
+	keyed := sorted(MapInto(it, func(v T) Pair[K, T] { return Pair[K, T]{X: key(v), Y: v} }),
+		func(a, b Pair[K, T]) bool { return a.X < b.X }, true, opts)
+	return MapInto(keyed, func(p Pair[K, T]) T { return p.Y })
+}
+
+func sorted[T any](it Iterator[T], less func(T, T) bool, stable bool, opts []SortOption) Iterator[T] {
+	c := sortConfig{workers: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return produceBatches(func(e *emitter[T]) {
+		var s []T
+		drain(it, e.flush, func(v T) {
+			s = append(s, v)
+		})
+		sortSlice(s, less, stable, c.workers)
+		for _, v := range s {
+			if !e.send(v) {
+				return
+			}
+		}
+	}, it)
+}
+
+// sortSlice sorts s using up to the given number of goroutines.
+func sortSlice[T any](s []T, less func(T, T) bool, stable bool, workers int) {
+	if n := len(s) / minParallelRun; n < workers {
+		workers = n
+	}
+	if workers <= 1 {
+		sortRun(s, less, stable)
+		return
+	}
+
+	bounds := make([]int, workers+1)
+	for i := range bounds {
+		bounds[i] = i * len(s) / workers
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(run []T) {
+			defer wg.Done()
+			sortRun(run, less, stable)
+		}(s[bounds[i]:bounds[i+1]])
+	}
+	wg.Wait()
+
+	// Merge neighbouring runs until a single one is left, alternating between s and buf.
+	buf := make([]T, len(s))
+	src, dst := s, buf
+	for len(bounds) > 2 {
+		merged := []int{0}
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo := bounds[i]
+			if i+2 >= len(bounds) {
+				copy(dst[lo:], src[lo:bounds[i+1]])
+				merged = append(merged, bounds[i+1])
+				continue
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				merge(dst[lo:hi], src[lo:mid], src[mid:hi], less)
+			}()
+			merged = append(merged, hi)
+		}
+		wg.Wait()
+		bounds = merged
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+func sortRun[T any](s []T, less func(T, T) bool, stable bool) {
+	if stable {
+		sort.SliceStable(s, func(i, j int) bool { return less(s[i], s[j]) })
+	} else {
+		sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
+	}
+}
+
+// merge merges the sorted slices a and b into dst, taking from a first on ties to stay stable.
+func merge[T any](dst, a, b []T, less func(T, T) bool) {
+	i, j := 0, 0
+	for k := range dst {
+		if j >= len(b) || (i < len(a) && !less(b[j], a[i])) {
+			dst[k] = a[i]
+			i++
+		} else {
+			dst[k] = b[j]
+			j++
+		}
+	}
+}
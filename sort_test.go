@@ -0,0 +1,95 @@
+package iter
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type sortRecord struct {
+	key, index int
+}
+
+func TestIterator_Sorted(t *testing.T) {
+	s := []int{5, 2, 8, 1, 9, 3}
+	asc := func(a, b int) bool { return a < b }
+	if v := fmt.Sprint(FromSlice(s).Sorted(asc).Collect()); v != "[1 2 3 5 8 9]" {
+		t.Errorf("Sorted did not work\nit: %s\n", v)
+	}
+	if v := FromSlice([]int{}).Sorted(asc).Collect(); len(v) != 0 {
+		t.Errorf("Sorted did not work for empty Iterators\nit: %v\n", v)
+	}
+
+	words := []string{"pear", "fig", "apple", "kiwi", "plum", "date"}
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	if v := fmt.Sprint(FromSlice(words).SortedStable(byLength).Collect()); v != "[fig pear kiwi plum date apple]" {
+		t.Errorf("SortedStable did not work\nit: %s\n", v)
+	}
+	length := func(s string) int { return len(s) }
+	if v := fmt.Sprint(SortedBy(FromSlice(words), length).Collect()); v != "[fig pear kiwi plum date apple]" {
+		t.Errorf("SortedBy did not work\nit: %s\n", v)
+	}
+
+	it, stopped := endless()
+	top := it.Take(100).Sorted(func(a, b int) bool { return a > b }).Take(1)
+	if v := top.Collect(); fmt.Sprint(v) != "[99]" {
+		t.Errorf("Sorted did not work on a prefix of an unbounded Iterator\nit: %v\n", v)
+	}
+	top.Close()
+	expectStopped(t, "Sorted", stopped)
+}
+
+func equalRecords(a, b []sortRecord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIterator_SortedParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	records := func(n int) []sortRecord {
+		s := make([]sortRecord, n)
+		for i := range s {
+			s[i] = sortRecord{key: r.Intn(100), index: i}
+		}
+		return s
+	}
+	less := func(a, b sortRecord) bool { return a.key < b.key }
+
+	for _, n := range []int{0, 10, 3 * minParallelRun, 4*minParallelRun + 7} {
+		s := records(n)
+		expected := append([]sortRecord(nil), s...)
+		sort.SliceStable(expected, func(i, j int) bool { return less(expected[i], expected[j]) })
+		for _, workers := range []int{2, 3, 8} {
+			stable := append([]sortRecord(nil), s...)
+			sortSlice(stable, less, true, workers)
+			if !equalRecords(stable, expected) {
+				t.Errorf("stable sort with %d workers did not work for %d elements", workers, n)
+			}
+			unstable := append([]sortRecord(nil), s...)
+			sortSlice(unstable, less, false, workers)
+			if !sort.SliceIsSorted(unstable, func(i, j int) bool { return less(unstable[i], unstable[j]) }) {
+				t.Errorf("sort with %d workers did not work for %d elements", workers, n)
+			}
+		}
+	}
+
+	s := records(2*minParallelRun + 1)
+	expected := append([]sortRecord(nil), s...)
+	sort.SliceStable(expected, func(i, j int) bool { return less(expected[i], expected[j]) })
+	if v := FromSlice(s).SortedStable(less, Parallel(4)).Collect(); !equalRecords(v, expected) {
+		t.Errorf("SortedStable did not work in parallel")
+	}
+	if v := SortedBy(FromSlice(s), func(r sortRecord) int { return r.key }, Parallel(4)).Collect(); !equalRecords(v, expected) {
+		t.Errorf("SortedBy did not work in parallel")
+	}
+
+	expectPanic(t, "iter: Parallel called with workers < 1", func() { Parallel(0) })
+}
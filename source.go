@@ -0,0 +1,53 @@
+package iter
+
+// Source is implemented by types that produce elements one at a time, like database cursors.
+//
+// Next returns the next element and true, or false once the Source is
+// exhausted. If a Source has a Close method, with or without an error result,
+// it is called once the Source is no longer read from. Iterator and
+// pull.Iterator both implement Source.
+type Source[T any] interface {
+	Next() (T, bool)
+}
+
+// Next receives the next element of the Iterator.
+//
+// It returns false once the Iterator is exhausted.
+func (it Iterator[T]) Next() (T, bool) {
+	v, ok := <-it
+	return v, ok
+}
+
+// FromSource creates an Iterator over the elements of s.
+//
+// The adapters of this package only operate on Iterators, so a Source has to
+// be converted before they can be used with it. The conversion costs a
+// goroutine, which calls Next until s is exhausted or the Iterator is closed,
+// then closes s. Errors returned by its Close method are discarded. If s is an
+// Iterator, it is returned as is. Use pull.FromSource to read s without a
+// goroutine.
+func FromSource[T any](s Source[T]) Iterator[T] {
+	if it, ok := s.(Iterator[T]); ok {
+		return it
+	}
+	return produce(func(e *emitter[T]) {
+		defer CloseSource(s)
+		for v, ok := s.Next(); ok; v, ok = s.Next() {
+			if !e.send(v) {
+				return
+			}
+		}
+	})
+}
+
+// CloseSource calls the Close method of s, if it has one.
+//
+// Errors returned by Close are discarded.
+func CloseSource[T any](s Source[T]) {
+	switch c := s.(type) {
+	case interface{ Close() error }:
+		c.Close()
+	case interface{ Close() }:
+		c.Close()
+	}
+}
@@ -0,0 +1,59 @@
+package iter
+
+import "testing"
+
+type cursor struct {
+	rows   []int
+	closed int
+}
+
+func (c *cursor) Next() (int, bool) {
+	if len(c.rows) == 0 {
+		return 0, false
+	}
+	v := c.rows[0]
+	c.rows = c.rows[1:]
+	return v, true
+}
+
+func (c *cursor) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestFromSource(t *testing.T) {
+	c := &cursor{rows: []int{1, 2, 3}}
+	v := FromSource[int](c).Map(func(x int) int { return x * 2 }).Collect()
+	if len(v) != 3 || v[0] != 2 || v[2] != 6 {
+		t.Errorf("FromSource did not work\nit: %v\n", v)
+	}
+	if c.closed != 1 {
+		t.Errorf("FromSource did not close the Source\nclosed: %d\n", c.closed)
+	}
+
+	c = &cursor{rows: make([]int, 1000)}
+	it := FromSource[int](c)
+	it.Take(2).Count()
+	for range it {
+	}
+	if c.closed != 1 {
+		t.Errorf("FromSource did not close the Source after Take\nclosed: %d\n", c.closed)
+	}
+
+	source := FromSlice([]int{1, 2})
+	if FromSource[int](source) != source {
+		t.Errorf("FromSource wrapped an Iterator")
+	}
+}
+
+func TestIterator_Next(t *testing.T) {
+	it := FromSlice([]int{1, 2})
+	var s Source[int] = it
+	if v, ok := s.Next(); !ok || v != 1 {
+		t.Errorf("Next did not work\nv: %d\nok: %v\n", v, ok)
+	}
+	it.Next()
+	if _, ok := it.Next(); ok {
+		t.Errorf("Next did not report the end of the Iterator")
+	}
+}
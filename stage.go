@@ -0,0 +1,451 @@
+package iter
+
+import "sync"
+
+// stages maps the Iterators created by this package to the stages producing them.
+var stages sync.Map
+
+// fuseStages enables the fusion of adapters into the goroutine producing their input.
+// It is only disabled by benchmarks to measure the effect of fusion.
+var fuseStages = true
+
+// batchStages enables the transport of elements in batches between stages.
+// It is only disabled by benchmarks to measure the effect of batching.
+var batchStages = true
+
+// batchSize is the maximum number of elements transported in one batch.
+const batchSize = 64
+
+// input is implemented by all Iterators, regardless of their element type.
+type input interface {
+	stage() *stage
+}
+
+// stage returns the stage producing the Iterator, or nil if there is none.
+func (it Iterator[T]) stage() *stage {
+	if s, ok := stages.Load(it); ok {
+		return s.(*stage)
+	}
+	return nil
+}
+
+// stage holds the lifecycle of the goroutine producing an Iterator.
+//
+// A stage stays registered after its goroutine returned as long as one of its
+// upstream stages is, so that closing its Iterator still reaches the inputs it
+// stopped reading early, as in Take. It is released once all upstream stages
+// are, because then there is nothing left to close.
+type stage struct {
+	done     chan struct{}
+	once     sync.Once
+	upstream []*stage
+	emitter  any
+	forget   func()
+
+	mu       sync.Mutex
+	pending  int
+	released bool
+	watchers []func()
+}
+
+func (s *stage) stop() {
+	s.once.Do(func() {
+		close(s.done)
+		for _, u := range s.upstream {
+			u.stop()
+		}
+	})
+}
+
+// finish is called once the goroutine of the stage returned. It releases the
+// stage as soon as no upstream stage is registered anymore.
+func (s *stage) finish() {
+	s.mu.Lock()
+	for _, u := range s.upstream {
+		if u.watch(s.upstreamReleased) {
+			s.pending++
+		}
+	}
+	last := s.pending == 0
+	s.mu.Unlock()
+	if last {
+		s.release()
+	}
+}
+
+func (s *stage) upstreamReleased() {
+	s.mu.Lock()
+	s.pending--
+	last := s.pending == 0
+	s.mu.Unlock()
+	if last {
+		s.release()
+	}
+}
+
+// watch registers f to be called when s is released. It returns false if s
+// was already released, in which case f is never called.
+func (s *stage) watch(f func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return false
+	}
+	s.watchers = append(s.watchers, f)
+	return true
+}
+
+// release removes the Iterators of the stage from stages.
+func (s *stage) release() {
+	s.mu.Lock()
+	if s.released {
+		s.mu.Unlock()
+		return
+	}
+	s.released = true
+	watchers := s.watchers
+	s.watchers = nil
+	s.mu.Unlock()
+	s.forget()
+	for _, f := range watchers {
+		f()
+	}
+}
+
+// emitter is used by a producing goroutine to hand elements to its Iterator.
+//
+// Adapters that neither block nor end the Iterator early, like Filter and Map,
+// do not start a goroutine of their own if their input comes from an emitter.
+// Instead, they are fused into it: the emitter applies their function to each
+// element before sending it, and sends to the channel of the adapter's
+// Iterator instead of the original one. This is only possible until the first
+// element has been received from the emitter's current channel, after which
+// the emitter is sealed. Fused functions run in the producing goroutine, so
+// adapters that do their work concurrently never have functions fused into
+// them; their output is produced by an emitter of their own.
+//
+// If the Iterator is drained by this package, e.g. by Collect or Fold, and the
+// emitter is batchable, the consumer attaches to it instead of receiving from
+// the channel. The emitter then collects up to batchSize elements and
+// hands them over at once, which amortizes the cost of the handoff. An emitter
+// is only batchable if its producer never blocks except in send, forEach and
+// drain, which flush the collected elements before waiting for input.
+// Otherwise, elements could be held back indefinitely, as in Tick. Consumers
+// that may stop early, like Take or Find, never attach, as the producer and
+// its fused functions would run ahead of them for elements nobody receives.
+type emitter[T any] struct {
+	done      <-chan struct{}
+	batchable bool
+
+	mu       sync.Mutex
+	c        chan T
+	fns      []func(T) (T, bool)
+	changed  chan struct{}
+	sealed   bool
+	finished bool
+	chans    []chan T
+	batch    chan []T
+	buf      []T
+}
+
+// send blocks until v was received. It returns false if the Iterator was closed,
+// in which case the producer should return.
+func (e *emitter[T]) send(v T) bool {
+	return e.sendUntil(v, nil)
+}
+
+// sendUntil works like send, but also gives up and returns false once cancel is closed.
+func (e *emitter[T]) sendUntil(v T, cancel <-chan struct{}) bool {
+	if e.sealed {
+		for _, f := range e.fns {
+			var ok bool
+			if v, ok = f(v); !ok {
+				return e.alive(cancel)
+			}
+		}
+		if e.batch != nil {
+			return e.push(v, cancel)
+		}
+		select {
+		case e.c <- v:
+			return true
+		case <-e.done:
+			return false
+		case <-cancel:
+			return false
+		}
+	}
+
+	applied := 0
+	for {
+		e.mu.Lock()
+		c, fns, changed, batch := e.c, e.fns, e.changed, e.batch
+		e.mu.Unlock()
+		for ; applied < len(fns); applied++ {
+			var ok bool
+			if v, ok = fns[applied](v); !ok {
+				return e.alive(cancel)
+			}
+		}
+		if batch != nil {
+			e.mu.Lock()
+			e.sealed = true
+			e.mu.Unlock()
+			return e.push(v, cancel)
+		}
+		select {
+		case c <- v:
+			e.mu.Lock()
+			e.sealed = true
+			e.mu.Unlock()
+			return true
+		case <-changed:
+		case <-e.done:
+			return false
+		case <-cancel:
+			return false
+		}
+	}
+}
+
+// alive reports whether the producer should go on after an element was dropped
+// by a fused function, so that producers are stopped even if nothing is sent.
+func (e *emitter[T]) alive(cancel <-chan struct{}) bool {
+	select {
+	case <-e.done:
+		return false
+	case <-cancel:
+		return false
+	default:
+		return true
+	}
+}
+
+// push adds v to the current batch, handing the batch over once it is full.
+func (e *emitter[T]) push(v T, cancel <-chan struct{}) bool {
+	if e.buf == nil {
+		e.buf = make([]T, 0, batchSize)
+	}
+	e.buf = append(e.buf, v)
+	if len(e.buf) < batchSize {
+		return true
+	}
+	return e.flushUntil(cancel)
+}
+
+// flush hands over the elements collected so far, if the emitter is attached to a batch consumer.
+//
+// It is called by forEach before waiting for input.
+func (e *emitter[T]) flush() {
+	e.flushUntil(nil)
+}
+
+func (e *emitter[T]) flushUntil(cancel <-chan struct{}) bool {
+	if len(e.buf) == 0 {
+		return true
+	}
+	select {
+	case e.batch <- e.buf:
+		e.buf = nil
+		return true
+	case <-e.done:
+		return false
+	case <-cancel:
+		return false
+	}
+}
+
+func (e *emitter[T]) finish() {
+	e.mu.Lock()
+	e.finished = true
+	batch := e.batch
+	e.mu.Unlock()
+	if batch != nil {
+		e.flush()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range e.chans {
+		close(c)
+	}
+	if batch != nil {
+		close(batch)
+	}
+}
+
+// forget removes all Iterators of the emitter from stages.
+func (e *emitter[T]) forget() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range e.chans {
+		stages.Delete(Iterator[T](c))
+	}
+}
+
+// produce runs f in a new goroutine and returns the Iterator of the elements it sends.
+//
+// The Iterator ends when f returns. Closing it also closes the given upstream
+// Iterators, which should be all Iterators f reads from.
+func produce[T any](f func(e *emitter[T]), upstream ...input) Iterator[T] {
+	return start(f, false, upstream)
+}
+
+// produceBatches works like produce, but allows the elements to be handed over in batches.
+//
+// f must not block except in the emitter's send and in forEach.
+func produceBatches[T any](f func(e *emitter[T]), upstream ...input) Iterator[T] {
+	return start(f, true, upstream)
+}
+
+func start[T any](f func(e *emitter[T]), batchable bool, upstream []input) Iterator[T] {
+	c := make(chan T)
+	it := Iterator[T](c)
+	s := &stage{done: make(chan struct{})}
+	for _, in := range upstream {
+		if u := in.stage(); u != nil {
+			s.upstream = append(s.upstream, u)
+		}
+	}
+	e := &emitter[T]{
+		done:      s.done,
+		batchable: batchable,
+		c:         c,
+		changed:   make(chan struct{}),
+		chans:     []chan T{c},
+	}
+	s.emitter = e
+	s.forget = e.forget
+	stages.Store(it, s)
+	go func() {
+		defer s.finish()
+		defer e.finish()
+		f(e)
+	}()
+	return it
+}
+
+// apply returns an Iterator of the elements of it passed through f, dropping those for which f returns false.
+//
+// If possible, f is fused into the goroutine producing it.
+func apply[T any](it Iterator[T], f func(T) (T, bool)) Iterator[T] {
+	if fused, ok := fuse(it, f); ok {
+		return fused
+	}
+	return produceBatches(func(e *emitter[T]) {
+		forEach(it, e.flush, func(v T) bool {
+			v, ok := f(v)
+			return !ok || e.send(v)
+		})
+	}, it)
+}
+
+// fuse appends f to the functions of the emitter producing it and returns the Iterator of the results.
+//
+// It returns false if it is not produced by an emitter that can still be fused.
+func fuse[T any](it Iterator[T], f func(T) (T, bool)) (Iterator[T], bool) {
+	if !fuseStages {
+		return nil, false
+	}
+	s, ok := stages.Load(it)
+	if !ok {
+		return nil, false
+	}
+	e, ok := s.(*stage).emitter.(*emitter[T])
+	if !ok {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sealed || e.finished || e.batch != nil || Iterator[T](e.c) != it {
+		return nil, false
+	}
+	e.c = make(chan T)
+	e.fns = append(e.fns, f)
+	close(e.changed)
+	e.changed = make(chan struct{})
+	fused := Iterator[T](e.c)
+	e.chans = append(e.chans, e.c)
+	stages.Store(fused, s)
+	return fused, true
+}
+
+// attach switches the emitter producing it to batches and returns the channel of the batches.
+//
+// It returns false if it is not produced by a batchable emitter that has not
+// handed over any elements yet.
+func attach[T any](it Iterator[T]) (chan []T, bool) {
+	if !batchStages {
+		return nil, false
+	}
+	s, ok := stages.Load(it)
+	if !ok {
+		return nil, false
+	}
+	e, ok := s.(*stage).emitter.(*emitter[T])
+	if !ok || !e.batchable {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.sealed || e.finished || e.batch != nil || Iterator[T](e.c) != it {
+		return nil, false
+	}
+	e.batch = make(chan []T)
+	close(e.changed)
+	e.changed = make(chan struct{})
+	return e.batch, true
+}
+
+// forEach calls f for the elements of it until f returns false or it ends.
+//
+// Elements are received one at a time, so that consumers that can stop early
+// never make the producer of it run ahead. If flush is not nil, it is called
+// before waiting for the next element, so that a batchable producer never
+// holds back elements while it blocks.
+func forEach[T any](it Iterator[T], flush func(), f func(T) bool) {
+	for {
+		v, ok := receive(it, flush)
+		if !ok || !f(v) {
+			return
+		}
+	}
+}
+
+// drain calls f for all elements of it, like forEach.
+//
+// As all elements are consumed anyway, they are received in batches if
+// possible, in which case the producer of it runs ahead by up to two batches.
+func drain[T any](it Iterator[T], flush func(), f func(T)) {
+	batch, ok := attach(it)
+	if !ok {
+		forEach(it, flush, func(v T) bool {
+			f(v)
+			return true
+		})
+		return
+	}
+	for {
+		b, ok := receive(batch, flush)
+		if !ok {
+			return
+		}
+		for _, v := range b {
+			f(v)
+		}
+	}
+}
+
+// receive receives from c, calling flush before it blocks.
+func receive[T any](c <-chan T, flush func()) (T, bool) {
+	if flush != nil {
+		select {
+		case v, ok := <-c:
+			return v, ok
+		default:
+			flush()
+		}
+	}
+	v, ok := <-c
+	return v, ok
+}
@@ -0,0 +1,232 @@
+package iter
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCompression is the compression of the Digests used by Summary.
+//
+// With it, quantiles are typically accurate to well below 1% of rank, more so
+// near the extremes.
+const DefaultCompression = 100
+
+// Digest is a t-digest, a sketch of a distribution for estimating its quantiles.
+//
+// A Digest summarizes any number of values in memory bounded by its
+// compression, and Digests of separate streams can be merged. Larger
+// compressions are more accurate but use more memory. The zero value is an
+// empty Digest with DefaultCompression.
+type Digest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min, max    float64
+}
+
+// centroid is a cluster of values, represented by their mean and number.
+type centroid struct {
+	mean, weight float64
+}
+
+// NewDigest creates an empty Digest with the given compression.
+//
+// NewDigest panics if compression is not positive.
+func NewDigest(compression float64) *Digest {
+	if !(compression > 0) {
+		panic("iter: non-positive compression for NewDigest")
+	}
+	return &Digest{compression: compression}
+}
+
+// Add adds the value x to the Digest.
+func (d *Digest) Add(x float64) {
+	d.add(centroid{mean: x, weight: 1})
+}
+
+func (d *Digest) add(c centroid) {
+	if d.compression == 0 {
+		d.compression = DefaultCompression
+	}
+	if d.count == 0 || c.mean < d.min {
+		d.min = c.mean
+	}
+	if d.count == 0 || c.mean > d.max {
+		d.max = c.mean
+	}
+	d.count += c.weight
+	d.buffer = append(d.buffer, c)
+	if len(d.buffer) >= 5*int(math.Ceil(d.compression)) {
+		d.compress()
+	}
+}
+
+// Merge adds all values summarized by other to the Digest.
+func (d *Digest) Merge(other *Digest) {
+	if other.count == 0 {
+		return
+	}
+	lo, hi := other.min, other.max
+	cs := append(append([]centroid(nil), other.centroids...), other.buffer...)
+	for _, c := range cs {
+		d.add(c)
+	}
+	// The means of the centroids of other may lie strictly inside its range.
+	d.min = math.Min(d.min, lo)
+	d.max = math.Max(d.max, hi)
+}
+
+// Count returns the number of values added to the Digest.
+func (d *Digest) Count() uint64 {
+	return uint64(d.count)
+}
+
+// scale maps the quantile q to the scale of the digest, on which every centroid spans at most 1.
+func (d *Digest) scale(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// compress merges the buffered values into the centroids.
+func (d *Digest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := make([]centroid, 0, len(d.centroids)+len(d.buffer))
+	all = append(append(all, d.centroids...), d.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	cur := all[0]
+	before := 0.0
+	for _, c := range all[1:] {
+		w := cur.weight + c.weight
+		if d.scale((before+w)/d.count)-d.scale(before/d.count) <= 1 {
+			cur.mean += (c.mean - cur.mean) * c.weight / w
+			cur.weight = w
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		cur = c
+	}
+	d.centroids = append(merged, cur)
+	d.buffer = d.buffer[:0]
+}
+
+// Quantile returns an estimate of the q-quantile of the values, e.g. the median for q = 0.5.
+//
+// q is clamped to [0, 1]. If the Digest is empty, NaN is returned.
+func (d *Digest) Quantile(q float64) float64 {
+	if d.count == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	d.compress()
+	if q <= 0 {
+		return d.min
+	}
+	if q >= 1 {
+		return d.max
+	}
+	target := q * d.count
+	cs := d.centroids
+	first, last := cs[0], cs[len(cs)-1]
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2)
+	}
+	// Interpolate linearly between the centers of neighbouring centroids.
+	center := first.weight / 2
+	for i := 1; i < len(cs); i++ {
+		next := center + cs[i-1].weight/2 + cs[i].weight/2
+		if target <= next {
+			return cs[i-1].mean + (cs[i].mean-cs[i-1].mean)*(target-center)/(next-center)
+		}
+		center = next
+	}
+	return last.mean + (d.max-last.mean)*(target-center)/(d.count-center)
+}
+
+// Summary holds statistics of a stream of values, computed in a single pass.
+//
+// Mean and variance are computed using Welford's algorithm, quantiles are
+// estimated using a Digest. Summaries of separate streams can be merged.
+// The zero value is an empty Summary.
+type Summary struct {
+	// Count is the number of values.
+	Count uint64
+	// Mean is the arithmetic mean of the values. It is only meaningful if Count is positive.
+	Mean float64
+	// Min and Max are the smallest and largest value. They are only meaningful if Count is positive.
+	Min, Max float64
+
+	m2     float64
+	digest Digest
+}
+
+// Stats consumes the Iterator, returning a Summary of its elements.
+//
+// The elements are converted to float64. Only the Digest of the Summary grows
+// with the number of elements, and it is bounded by its compression.
+func Stats[T Number](it Iterator[T]) *Summary {
+	s := &Summary{}
+	drain(it, nil, func(v T) {
+		s.Add(float64(v))
+	})
+	return s
+}
+
+// Add adds the value x to the Summary.
+func (s *Summary) Add(x float64) {
+	if s.Count == 0 || x < s.Min {
+		s.Min = x
+	}
+	if s.Count == 0 || x > s.Max {
+		s.Max = x
+	}
+	s.Count++
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.Count)
+	s.m2 += delta * (x - s.Mean)
+	s.digest.Add(x)
+}
+
+// Merge adds all values summarized by other to the Summary.
+func (s *Summary) Merge(other *Summary) {
+	if other.Count == 0 {
+		return
+	}
+	s.digest.Merge(&other.digest)
+	if s.Count == 0 {
+		s.Count, s.Mean, s.Min, s.Max, s.m2 = other.Count, other.Mean, other.Min, other.Max, other.m2
+		return
+	}
+	n := float64(s.Count + other.Count)
+	delta := other.Mean - s.Mean
+	s.m2 += other.m2 + delta*delta*float64(s.Count)*float64(other.Count)/n
+	s.Mean += delta * float64(other.Count) / n
+	s.Count += other.Count
+	s.Min = math.Min(s.Min, other.Min)
+	s.Max = math.Max(s.Max, other.Max)
+}
+
+// Variance returns the sample variance of the values, or NaN if there are fewer than two.
+func (s *Summary) Variance() float64 {
+	if s.Count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count-1)
+}
+
+// StdDev returns the sample standard deviation of the values, or NaN if there are fewer than two.
+func (s *Summary) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Quantile returns an estimate of the q-quantile of the values, see Digest.Quantile.
+func (s *Summary) Quantile(q float64) float64 {
+	return s.digest.Quantile(q)
+}
+
+// Median returns an estimate of the median of the values, or NaN if there are none.
+func (s *Summary) Median() float64 {
+	return s.Quantile(0.5)
+}
@@ -0,0 +1,92 @@
+package iter
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func approx(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestStats(t *testing.T) {
+	s := Stats(FromSlice([]int{2, 4, 4, 4, 5, 5, 7, 9}))
+	if s.Count != 8 || s.Mean != 5 || s.Min != 2 || s.Max != 9 {
+		t.Errorf("Stats did not work\nsummary: %+v\n", s)
+	}
+	if !approx(s.Variance(), 32.0/7, 1e-12) || !approx(s.StdDev(), math.Sqrt(32.0/7), 1e-12) {
+		t.Errorf("Variance did not work\nvariance: %f\n", s.Variance())
+	}
+	if s.Median() != 4.5 {
+		t.Errorf("Median did not work\nmedian: %f\n", s.Median())
+	}
+	if s.Quantile(0) != 2 || s.Quantile(1) != 9 {
+		t.Errorf("Quantile did not return the extremes\nquantiles: %f %f\n", s.Quantile(0), s.Quantile(1))
+	}
+
+	empty := Stats(FromSlice([]float64{}))
+	if empty.Count != 0 || !math.IsNaN(empty.Variance()) || !math.IsNaN(empty.Median()) {
+		t.Errorf("Stats did not work for empty Iterators\nsummary: %+v\n", empty)
+	}
+	one := Stats(FromSlice([]float64{3}))
+	if one.Mean != 3 || !math.IsNaN(one.Variance()) || one.Median() != 3 {
+		t.Errorf("Stats did not work for one element\nsummary: %+v\n", one)
+	}
+}
+
+func TestStats_Quantiles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]float64, 100000)
+	for i := range values {
+		values[i] = r.ExpFloat64()
+	}
+	s := Stats(FromSlice(values))
+	sort.Float64s(values)
+	if len(s.digest.centroids) > 5*DefaultCompression {
+		t.Errorf("Digest is not bounded\ncentroids: %d\n", len(s.digest.centroids))
+	}
+	for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+		estimate := s.Quantile(q)
+		rank := float64(sort.SearchFloat64s(values, estimate)) / float64(len(values))
+		if !approx(rank, q, 0.01) {
+			t.Errorf("Quantile(%v) is inaccurate\nestimate: %f\nrank: %f\n", q, estimate, rank)
+		}
+	}
+}
+
+func TestSummary_Merge(t *testing.T) {
+	var all, a, b Summary
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		v := r.NormFloat64()*10 + 50
+		all.Add(v)
+		if i%3 == 0 {
+			a.Add(v)
+		} else {
+			b.Add(v)
+		}
+	}
+	var merged Summary
+	merged.Merge(&a)
+	merged.Merge(&b)
+	if merged.Count != all.Count || merged.Min != all.Min || merged.Max != all.Max ||
+		!approx(merged.Mean, all.Mean, 1e-9) || !approx(merged.Variance(), all.Variance(), 1e-6) {
+		t.Errorf("Merge did not work\nmerged: %+v\nall: %+v\n", merged, all)
+	}
+	if !approx(merged.Median(), all.Median(), 0.5) || !approx(merged.Quantile(0.9), all.Quantile(0.9), 0.5) {
+		t.Errorf("Merge did not merge the quantiles\nmerged: %f\nall: %f\n", merged.Median(), all.Median())
+	}
+}
+
+func TestNewDigestInvalid(t *testing.T) {
+	expectPanic(t, "iter: non-positive compression for NewDigest", func() { NewDigest(0) })
+	d := NewDigest(20)
+	for i := 1; i <= 1000; i++ {
+		d.Add(float64(i))
+	}
+	if d.Count() != 1000 || !approx(d.Quantile(0.5), 500, 25) {
+		t.Errorf("Digest did not work\nmedian: %f\n", d.Quantile(0.5))
+	}
+}
@@ -0,0 +1,89 @@
+package iter
+
+import "time"
+
+// Clock creates the tickers used by the time based sources.
+//
+// It can be replaced in tests to control the passing of time.
+type Clock interface {
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers the time on a channel in regular intervals until it is stopped, like time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{t: time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	t *time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTicker) Stop() {
+	t.t.Stop()
+}
+
+// Tick creates an infinite Iterator producing the current time every interval d.
+//
+// Like time.Ticker, ticks are dropped if the consumer is too slow. The ticker
+// is stopped when the Iterator is closed. d must be greater than zero.
+func Tick(d time.Duration) Iterator[time.Time] {
+	return TickWithClock(SystemClock, d)
+}
+
+// TickWithClock works like Tick, using the given Clock as the source of time.
+func TickWithClock(c Clock, d time.Duration) Iterator[time.Time] {
+	if d <= 0 {
+		panic("iter: non-positive interval for Tick")
+	}
+	t := c.NewTicker(d)
+	return produce(func(e *emitter[time.Time]) {
+		defer t.Stop()
+		for {
+			select {
+			case now := <-t.C():
+				if !e.send(now) {
+					return
+				}
+			case <-e.done:
+				return
+			}
+		}
+	})
+}
+
+// Timer creates an Iterator producing the current time once after the duration d.
+//
+// d must be greater than zero.
+func Timer(d time.Duration) Iterator[time.Time] {
+	return TimerWithClock(SystemClock, d)
+}
+
+// TimerWithClock works like Timer, using the given Clock as the source of time.
+func TimerWithClock(c Clock, d time.Duration) Iterator[time.Time] {
+	if d <= 0 {
+		panic("iter: non-positive duration for Timer")
+	}
+	t := c.NewTicker(d)
+	return produce(func(e *emitter[time.Time]) {
+		defer t.Stop()
+		select {
+		case now := <-t.C():
+			e.send(now)
+		case <-e.done:
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package iter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only passes when it is advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+type fakeTicker struct {
+	c       chan time.Time
+	d       time.Duration
+	next    time.Time
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{c: make(chan time.Time, 1), d: d, next: c.now.Add(d), stopped: make(chan struct{})}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.d)
+		}
+	}
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.once.Do(func() { close(t.stopped) })
+}
+
+func receiveTime(t *testing.T, it Iterator[time.Time]) (time.Time, bool) {
+	t.Helper()
+	select {
+	case v, ok := <-it:
+		return v, ok
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the Iterator")
+		return time.Time{}, false
+	}
+}
+
+func TestTickWithClock(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.now
+	it := TickWithClock(clock, time.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		v, ok := receiveTime(t, it)
+		if !ok || !v.Equal(start.Add(time.Duration(i)*time.Second)) {
+			t.Errorf("Tick did not work\ntick: %v\nexpected: %v\n", v, start.Add(time.Duration(i)*time.Second))
+		}
+	}
+
+	it.Close()
+	if _, ok := receiveTime(t, it); ok {
+		t.Error("Tick did not end after Close")
+	}
+	select {
+	case <-clock.tickers[0].stopped:
+	case <-time.After(2 * time.Second):
+		t.Error("Tick did not stop its ticker")
+	}
+}
+
+func TestTickPipelineClose(t *testing.T) {
+	clock := newFakeClock()
+	it := MapInto(TickWithClock(clock, time.Minute), func(t time.Time) int { return t.Minute() }).
+		Filter(func(m int) bool { return m%2 == 0 })
+	go func() {
+		for i := 0; i < 10; i++ {
+			clock.Advance(time.Minute)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	if m, ok := <-it; !ok || m%2 != 0 {
+		t.Errorf("Tick pipeline did not work\nminute: %d\n", m)
+	}
+
+	it.Close()
+	select {
+	case <-clock.tickers[0].stopped:
+	case <-time.After(2 * time.Second):
+		t.Error("Closing the pipeline did not stop the ticker")
+	}
+	for range it {
+	}
+}
+
+func TestTimerWithClock(t *testing.T) {
+	clock := newFakeClock()
+	it := TimerWithClock(clock, time.Hour)
+	clock.Advance(time.Hour)
+	v, ok := receiveTime(t, it)
+	if !ok || !v.Equal(clock.now) {
+		t.Errorf("Timer did not work\ntime: %v\nexpected: %v\n", v, clock.now)
+	}
+	if _, ok := receiveTime(t, it); ok {
+		t.Error("Timer did not end after firing")
+	}
+}
+
+func TestTickInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Tick did not panic for a zero interval")
+		}
+	}()
+	Tick(0)
+}